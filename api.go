@@ -14,6 +14,32 @@ type Traverser interface {
 	Retrieve(data any, path []Key) (any, error)
 }
 
+// MatchTraverser is an optional interface that a Traverser can implement in order to report the concrete path of each result.
+type MatchTraverser interface {
+	RetrieveMatches(data any, path []Key) ([]Match, error)
+}
+
+// Setter is an optional interface that a Traverser can implement in order to support writing values.
+// Set returns the data after setting the value, which might be a new value (e.g. if the data is nil).
+type Setter interface {
+	Set(data any, path []Key, value any) (any, error)
+}
+
+// Deleter is an optional interface that a Traverser can implement in order to support removing values.
+// Delete returns the data after removing the value, which might be a new value (e.g. if a slice shrinks).
+type Deleter interface {
+	Delete(data any, path []Key) (any, error)
+}
+
+// Traversable is an interface that custom container types (e.g. ordered maps, lazily loaded records) can implement
+// in order to be traversed by the DefaultTraverser. PickKey returns the value that the key (a field or an index key) addresses,
+// or an error that wraps ErrFieldNotFound (or ErrIndexOutOfRange) if there is none.
+// In order to support wildcards, filters, recursive descent, Each, Map and Len, the type can also implement
+// iter.Iterable (elements) or iter.FieldIterable (fields) and iter.Lengther.
+type Traversable interface {
+	PickKey(key Key) (any, error)
+}
+
 type ErrorGatherer interface {
 	GatherSelector(selector string, err error)
 }
@@ -0,0 +1,134 @@
+package pick
+
+import (
+	"reflect"
+)
+
+// cloneValue returns a deep copy of the value. Maps, slices, arrays, pointers, interfaces and the exported fields of structs are copied
+// recursively, while any other value (and the unexported fields of structs) is copied as is. A pointer, map or slice that is reached
+// more than once (e.g. a cycle) is copied once, so the copy keeps the same references.
+func cloneValue(v any) any {
+	// attempts to fast return without reflect.
+	switch v.(type) {
+	case nil, string, bool, int, int64, float64:
+		return v
+	}
+
+	return cloner{}.value(v)
+}
+
+// cloner holds the copies of the references that are already (or are being) copied.
+type cloner map[reference]reflect.Value
+
+func (c cloner) value(v any) any {
+	// attempts to fast return without reflect.
+	switch t := v.(type) {
+	case nil, string, bool, int, int64, float64:
+		return v
+	case map[string]any:
+		ref, isRef := referenceOf(reflect.ValueOf(t))
+		if !isRef {
+			return t
+		}
+		if cloned, found := c[ref]; found {
+			return cloned.Interface()
+		}
+		cloned := make(map[string]any, len(t))
+		c[ref] = reflect.ValueOf(cloned)
+		for k, e := range t {
+			cloned[k] = c.value(e)
+		}
+		return cloned
+	case []any:
+		ref, isRef := referenceOf(reflect.ValueOf(t))
+		if !isRef {
+			if t == nil {
+				return t
+			}
+			return []any{}
+		}
+		if cloned, found := c[ref]; found {
+			return cloned.Interface()
+		}
+		cloned := make([]any, len(t))
+		c[ref] = reflect.ValueOf(cloned)
+		for i, e := range t {
+			cloned[i] = c.value(e)
+		}
+		return cloned
+	}
+
+	return c.reflect(reflect.ValueOf(v)).Interface()
+}
+
+func (c cloner) reflect(v reflect.Value) reflect.Value {
+	ref, isRef := referenceOf(v)
+	if isRef {
+		if cloned, found := c[ref]; found {
+			return cloned
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.New(v.Type().Elem())
+		c[ref] = cloned
+		cloned.Elem().Set(c.reflect(v.Elem()))
+		return cloned
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(c.reflect(v.Elem()))
+		return cloned
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c[ref] = cloned
+		iter := v.MapRange()
+		for iter.Next() {
+			cloned.SetMapIndex(iter.Key(), c.reflect(iter.Value()))
+		}
+		return cloned
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if isRef {
+			c[ref] = cloned
+		}
+		for i := range v.Len() {
+			cloned.Index(i).Set(c.reflect(v.Index(i)))
+		}
+		return cloned
+
+	case reflect.Array:
+		cloned := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			cloned.Index(i).Set(c.reflect(v.Index(i)))
+		}
+		return cloned
+
+	case reflect.Struct:
+		cloned := addressableCopy(v)
+		for i := range v.NumField() {
+			if f := cloned.Field(i); f.CanSet() {
+				f.Set(c.reflect(v.Field(i)))
+			}
+		}
+		return cloned
+
+	default:
+		return v
+	}
+}
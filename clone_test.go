@@ -0,0 +1,63 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneValue(t *testing.T) {
+	type item struct {
+		Ptr   *int
+		Tags  []string
+		Attrs map[string]any
+		Arr   [1][]int
+	}
+
+	n := 1
+	src := map[string]any{
+		"items": []any{&item{Ptr: &n, Tags: []string{"a"}, Attrs: map[string]any{"x": []any{1}}, Arr: [1][]int{{1}}}},
+		"typed": map[string][]int{"a": {1}},
+		"nil":   nil,
+	}
+
+	cloned := cloneValue(src).(map[string]any) //nolint:forcetypeassert // test.
+	testingx.AssertEqual(t, any(cloned), any(src))
+
+	c := cloned["items"].([]any)[0].(*item) //nolint:forcetypeassert // test.
+	*c.Ptr = 2
+	c.Tags[0] = "b"
+	c.Attrs["x"].([]any)[0] = 2 //nolint:forcetypeassert // test.
+	c.Arr[0][0] = 2
+	cloned["typed"].(map[string][]int)["a"][0] = 2 //nolint:forcetypeassert // test.
+
+	testingx.AssertEqual(t, n, 1)
+	testingx.AssertEqual(t, any(src), any(map[string]any{
+		"items": []any{&item{Ptr: &n, Tags: []string{"a"}, Attrs: map[string]any{"x": []any{1}}, Arr: [1][]int{{1}}}},
+		"typed": map[string][]int{"a": {1}},
+		"nil":   nil,
+	}))
+}
+
+func TestCloneValueCycles(t *testing.T) {
+	type node struct {
+		Parent   *node
+		Children []*node
+		Name     string
+	}
+
+	root := &node{Name: "root"}
+	root.Children = []*node{{Name: "child", Parent: root}}
+	cloned := cloneValue(root).(*node) //nolint:forcetypeassert // test.
+	require.NotSame(t, root, cloned)
+	require.Same(t, cloned, cloned.Children[0].Parent)
+	testingx.AssertEqual(t, cloned.Children[0].Name, "child")
+
+	self := map[string]any{"name": "self"}
+	self["self"] = self
+	clonedMap := cloneValue(self).(map[string]any) //nolint:forcetypeassert // test.
+	clonedMap["name"] = "changed"
+	testingx.AssertEqual(t, clonedMap["self"].(map[string]any)["name"], any("changed")) //nolint:forcetypeassert // test.
+	testingx.AssertEqual(t, self["name"], any("self"))
+}
@@ -0,0 +1,231 @@
+package pick
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/moukoublen/pick/iter"
+)
+
+// ChangeType is the type of a Change.
+type ChangeType int
+
+const (
+	ChangeAdded ChangeType = iota + 1
+	ChangeRemoved
+	ChangeChanged
+)
+
+func (c ChangeType) String() string {
+	switch c {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeChanged:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is a single difference between two values. From is the value in the first picker (nil if the value was added)
+// and To is the value in the second picker (nil if the value was removed).
+type Change struct {
+	From any
+	To   any
+	Path []Key
+	Type ChangeType
+}
+
+type diffOptions struct {
+	a, b      Picker                    // the compared pickers, which resolve the fields of the structs of each side.
+	converter Converter                 // the converter of the first picker, set only if convert is true.
+	comparing map[[2]reference]struct{} // the pairs of references that are being compared, in order to detect cycles.
+	convert   bool
+}
+
+type DiffOption func(o *diffOptions)
+
+// WithDiffConverter compares the values that are not equal by converting the second value to the type of the first one (and vice versa)
+// using the converter of the first picker, so e.g. `"1"` and `1` are treated as equal.
+func WithDiffConverter() DiffOption {
+	return func(o *diffOptions) {
+		o.convert = true
+	}
+}
+
+// Diff walks both pickers' data and returns the changes that turn the data of a into the data of b.
+// Objects (maps and structs) are compared field by field (fields are visited in sorted order) and slices/arrays index by index.
+// Values are compared using the JSON semantics (e.g. numbers are equal if they have the same numeric value regardless of their type).
+// The removals of slice elements are listed from the last to the first index, so that the result can be applied in order (see AsPatch).
+// Cycles are not followed: values that are reached again while they are being compared are treated as equal.
+func Diff(a, b Picker, opts ...DiffOption) ([]Change, error) {
+	o := diffOptions{a: a, b: b, comparing: map[[2]reference]struct{}{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.convert {
+		o.converter = a.Converter
+	}
+
+	var changes []Change
+	err := o.diff(nil, a.data, b.data, &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (o diffOptions) diff(path []Key, a, b any, changes *[]Change) error {
+	// a pair of values that is already being compared (a cycle in both values) has no other changes.
+	refA, isRefA := referenceOf(reflect.ValueOf(a))
+	refB, isRefB := referenceOf(reflect.ValueOf(b))
+	if isRefA && isRefB {
+		pair := [2]reference{refA, refB}
+		if _, found := o.comparing[pair]; found {
+			return nil
+		}
+		o.comparing[pair] = struct{}{}
+		defer delete(o.comparing, pair)
+	}
+
+	switch {
+	case isMergeObject(a) && isMergeObject(b):
+		return o.diffObjects(path, a, b, changes)
+
+	case isMergeSlice(a) && isMergeSlice(b):
+		return o.diffSlices(path, a, b, changes)
+
+	case !o.equal(a, b):
+		*changes = append(*changes, Change{Type: ChangeChanged, Path: path, From: a, To: b})
+	}
+
+	return nil
+}
+
+func (o diffOptions) diffObjects(path []Key, a, b any, changes *[]Change) error {
+	fieldsA, err := objectFields(o.a, a)
+	if err != nil {
+		return err
+	}
+	fieldsB, err := objectFields(o.b, b)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(fieldsA)+len(fieldsB))
+	for name := range fieldsA {
+		names = append(names, name)
+	}
+	for name := range fieldsB {
+		if _, found := fieldsA[name]; !found {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		fieldPath := appendKey(path, Field(name))
+		va, inA := fieldsA[name]
+		vb, inB := fieldsB[name]
+		switch {
+		case !inB:
+			*changes = append(*changes, Change{Type: ChangeRemoved, Path: fieldPath, From: va})
+		case !inA:
+			*changes = append(*changes, Change{Type: ChangeAdded, Path: fieldPath, To: vb})
+		default:
+			if err := o.diff(fieldPath, va, vb, changes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o diffOptions) diffSlices(path []Key, a, b any, changes *[]Change) error {
+	asAny := iter.MapOpFn(func(item any) (any, error) { return item, nil })
+	elementsA, err := iter.Map(a, asAny)
+	if err != nil {
+		return err
+	}
+	elementsB, err := iter.Map(b, asAny)
+	if err != nil {
+		return err
+	}
+
+	common := min(len(elementsA), len(elementsB))
+	for i := range common {
+		if err := o.diff(appendKey(path, Index(i)), elementsA[i], elementsB[i], changes); err != nil {
+			return err
+		}
+	}
+	for i := common; i < len(elementsB); i++ {
+		*changes = append(*changes, Change{Type: ChangeAdded, Path: appendKey(path, Index(i)), To: elementsB[i]})
+	}
+	for i := len(elementsA) - 1; i >= common; i-- {
+		*changes = append(*changes, Change{Type: ChangeRemoved, Path: appendKey(path, Index(i)), From: elementsA[i]})
+	}
+
+	return nil
+}
+
+// equal compares two values using the JSON semantics and, if a converter is set, by converting each value to the type of the other one.
+func (o diffOptions) equal(a, b any) bool {
+	if valuesEqual(a, b) {
+		return true
+	}
+	if o.converter == nil || a == nil || b == nil {
+		return false
+	}
+
+	if converted, err := o.converter.ByType(b, reflect.TypeOf(a)); err == nil && valuesEqual(a, converted) {
+		return true
+	}
+	if converted, err := o.converter.ByType(a, reflect.TypeOf(b)); err == nil && valuesEqual(converted, b) {
+		return true
+	}
+
+	return false
+}
+
+// objectFields returns the fields of a map or a struct, as they are resolved by the picker.
+func objectFields(p Picker, object any) (map[string]any, error) {
+	fields := map[string]any{}
+	err := p.eachField(object, func(item any, meta iter.FieldOpMeta) error {
+		fields[meta.Name] = item
+		return nil
+	})
+
+	return fields, err
+}
+
+// appendKey returns a new path that consists of the path followed by the key, without modifying the backing array of the path.
+func appendKey(path []Key, key Key) []Key {
+	return append(slices.Clip(path), key)
+}
+
+// AsPatch converts the changes (as returned by Diff) to JSON Patch (RFC 6902) operations, using JSON Pointer paths.
+// Added values become add operations, removed values remove operations and changed values replace operations.
+func AsPatch(changes []Change) []PatchOperation {
+	n := JSONPointerNotation{}
+	ops := make([]PatchOperation, 0, len(changes))
+	for _, c := range changes {
+		op := PatchOperation{Path: n.Format(c.Path...)}
+		switch c.Type {
+		case ChangeAdded:
+			op.Op, op.Value = PatchOpAdd, c.To
+		case ChangeRemoved:
+			op.Op = PatchOpRemove
+		case ChangeChanged:
+			op.Op, op.Value = PatchOpReplace, c.To
+		default:
+			continue
+		}
+		ops = append(ops, op)
+	}
+
+	return ops
+}
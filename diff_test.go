@@ -0,0 +1,185 @@
+package pick
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		a             string
+		b             string
+		opts          []DiffOption
+		expected      []Change
+		errorAsserter tst.ErrorAssertionFunc
+	}{
+		"equal": {
+			a:             `{"a": [1, {"b": null}], "c": "d"}`,
+			b:             `{"c": "d", "a": [1.0, {"b": null}]}`,
+			expected:      nil,
+			errorAsserter: tst.NoError(),
+		},
+		"objects": {
+			a: `{"a": 1, "b": {"c": "x", "d": true}, "e": "removed"}`,
+			b: `{"a": 2, "b": {"c": "x", "d": false, "f": [1]}, "g": null}`,
+			expected: []Change{
+				{Type: ChangeChanged, Path: []Key{Field("a")}, From: float64(1), To: float64(2)},
+				{Type: ChangeChanged, Path: []Key{Field("b"), Field("d")}, From: true, To: false},
+				{Type: ChangeAdded, Path: []Key{Field("b"), Field("f")}, To: []any{float64(1)}},
+				{Type: ChangeRemoved, Path: []Key{Field("e")}, From: "removed"},
+				{Type: ChangeAdded, Path: []Key{Field("g")}, To: nil},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"slices": {
+			a: `{"a": [1, 2, 3, 4], "b": [1]}`,
+			b: `{"a": [1, 5], "b": [1, 2, 3]}`,
+			expected: []Change{
+				{Type: ChangeChanged, Path: []Key{Field("a"), Index(1)}, From: float64(2), To: float64(5)},
+				{Type: ChangeRemoved, Path: []Key{Field("a"), Index(3)}, From: float64(4)},
+				{Type: ChangeRemoved, Path: []Key{Field("a"), Index(2)}, From: float64(3)},
+				{Type: ChangeAdded, Path: []Key{Field("b"), Index(1)}, To: float64(2)},
+				{Type: ChangeAdded, Path: []Key{Field("b"), Index(2)}, To: float64(3)},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"type change": {
+			a: `{"a": {"b": 1}, "c": [1]}`,
+			b: `{"a": [1], "c": "1"}`,
+			expected: []Change{
+				{Type: ChangeChanged, Path: []Key{Field("a")}, From: map[string]any{"b": float64(1)}, To: []any{float64(1)}},
+				{Type: ChangeChanged, Path: []Key{Field("c")}, From: []any{float64(1)}, To: "1"},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"root": {
+			a:             `"a"`,
+			b:             `"b"`,
+			expected:      []Change{{Type: ChangeChanged, Path: nil, From: "a", To: "b"}},
+			errorAsserter: tst.NoError(),
+		},
+		"without conversion": {
+			a: `{"a": 1, "b": "true"}`,
+			b: `{"a": "1", "b": true}`,
+			expected: []Change{
+				{Type: ChangeChanged, Path: []Key{Field("a")}, From: float64(1), To: "1"},
+				{Type: ChangeChanged, Path: []Key{Field("b")}, From: "true", To: true},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"with conversion": {
+			a:    `{"a": 1, "b": "true", "c": "x", "d": null}`,
+			b:    `{"a": "1", "b": true, "c": 1, "d": 0}`,
+			opts: []DiffOption{WithDiffConverter()},
+			expected: []Change{
+				{Type: ChangeChanged, Path: []Key{Field("c")}, From: "x", To: float64(1)},
+				{Type: ChangeChanged, Path: []Key{Field("d")}, From: nil, To: float64(0)},
+			},
+			errorAsserter: tst.NoError(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := WrapJSON([]byte(tc.a))
+			require.NoError(t, err)
+			b, err := WrapJSON([]byte(tc.b))
+			require.NoError(t, err)
+
+			got, err := Diff(a, b, tc.opts...)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+
+			// applying the changes as a patch to a results in b (when values are not compared through conversion).
+			if len(tc.opts) == 0 {
+				require.NoError(t, a.ApplyPatch(AsPatch(got)))
+				testingx.AssertEqual(t, a.Data(), b.Data())
+			}
+		})
+	}
+}
+
+func TestDiffTypedData(t *testing.T) {
+	type item struct {
+		Tags []string
+		ID   int
+	}
+
+	changes, err := Diff(Wrap(item{ID: 1, Tags: []string{"a"}}), Wrap(map[string]any{"ID": 1.0, "Tags": []any{"a", "b"}}))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, changes, []Change{
+		{Type: ChangeAdded, Path: []Key{Field("Tags"), Index(1)}, To: "b"},
+	})
+	testingx.AssertEqual(t, ChangeAdded.String(), "added")
+}
+
+func TestDiffStructTags(t *testing.T) {
+	type embedded struct {
+		ID int `yaml:"id"`
+	}
+	type item struct {
+		embedded
+		Name string `yaml:"name"`
+	}
+
+	c := NewDefaultConverter()
+	a := NewPicker(item{embedded: embedded{ID: 1}, Name: "a"}, NewDefaultTraverser(c, WithStructTags("yaml")), c, DotNotation{})
+	b := a.Wrap(map[string]any{"id": 2, "name": "a"})
+
+	changes, err := Diff(a, b)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, changes, []Change{{Type: ChangeChanged, Path: []Key{Field("id")}, From: 1, To: 2}})
+
+	merged, err := Merge(a, b)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, merged.Data(), any(map[string]any{"id": 2, "name": "a"}))
+}
+
+func TestAsPatch(t *testing.T) {
+	ops := AsPatch([]Change{
+		{Type: ChangeAdded, Path: []Key{Field("a/b"), Index(0)}, To: 1},
+		{Type: ChangeRemoved, Path: []Key{Field("m~n")}, From: 2},
+		{Type: ChangeChanged, Path: nil, From: 3, To: 4},
+		{Type: ChangeAdded, Path: []Key{Field("x")}, To: nil},
+		{Type: ChangeChanged, Path: []Key{Field("y")}, From: 5, To: nil},
+	})
+	testingx.AssertEqual(t, ops, []PatchOperation{
+		{Op: PatchOpAdd, Path: "/a~1b/0", Value: 1},
+		{Op: PatchOpRemove, Path: "/m~0n"},
+		{Op: PatchOpReplace, Path: "", Value: 4},
+		{Op: PatchOpAdd, Path: "/x", Value: nil},
+		{Op: PatchOpReplace, Path: "/y", Value: nil},
+	})
+
+	// the null values are kept when the operations are encoded.
+	js, err := json.Marshal(ops[3:])
+	require.NoError(t, err)
+	testingx.AssertEqual(t, string(js), `[{"op":"add","path":"/x","value":null},{"op":"replace","path":"/y","value":null}]`)
+}
+
+func TestDiffCycles(t *testing.T) {
+	type node struct {
+		Parent   *node   `json:"parent"`
+		Name     string  `json:"name"`
+		Children []*node `json:"children"`
+	}
+	tree := func(childName string) *node {
+		root := &node{Name: "root"}
+		root.Children = []*node{{Name: childName, Parent: root}}
+		return root
+	}
+
+	changes, err := Diff(Wrap(tree("a")), Wrap(tree("a")))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, len(changes), 0)
+
+	changes, err = Diff(Wrap(tree("a")), Wrap(tree("b")))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, changes, []Change{
+		{Type: ChangeChanged, Path: []Key{Field("children"), Index(0), Field("name")}, From: "a", To: "b"},
+	})
+}
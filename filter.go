@@ -0,0 +1,422 @@
+package pick
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFilterExpression is returned when a filter expression (e.g. `[?(@.type == "primary")]`) cannot be parsed.
+var ErrInvalidFilterExpression = fmt.Errorf("%w: invalid filter expression", ErrInvalidSelectorFormat)
+
+// filterExpression is a compiled filter expression that is evaluated against each one of the candidate elements.
+type filterExpression interface {
+	match(d DefaultTraverser, current any) bool
+}
+
+type filterOr struct{ left, right filterExpression }
+
+func (f filterOr) match(d DefaultTraverser, current any) bool {
+	return f.left.match(d, current) || f.right.match(d, current)
+}
+
+type filterAnd struct{ left, right filterExpression }
+
+func (f filterAnd) match(d DefaultTraverser, current any) bool {
+	return f.left.match(d, current) && f.right.match(d, current)
+}
+
+type filterNot struct{ expr filterExpression }
+
+func (f filterNot) match(d DefaultTraverser, current any) bool {
+	return !f.expr.match(d, current)
+}
+
+// filterExists matches when the relative path exists in the current element (e.g. `@.email`).
+type filterExists struct{ path []Key }
+
+func (f filterExists) match(d DefaultTraverser, current any) bool {
+	_, err := d.Retrieve(current, f.path)
+	return err == nil
+}
+
+type filterOperator int
+
+const (
+	filterOperatorEqual filterOperator = iota
+	filterOperatorNotEqual
+	filterOperatorLess
+	filterOperatorLessOrEqual
+	filterOperatorGreater
+	filterOperatorGreaterOrEqual
+)
+
+// filterOperators is ordered so that the two character operators are checked first.
+var filterOperators = []struct {
+	token string
+	op    filterOperator
+}{
+	{"==", filterOperatorEqual},
+	{"!=", filterOperatorNotEqual},
+	{"<=", filterOperatorLessOrEqual},
+	{">=", filterOperatorGreaterOrEqual},
+	{"<", filterOperatorLess},
+	{">", filterOperatorGreater},
+}
+
+// filterOperand is either a relative path (e.g. `@.type`) or a literal (e.g. `"primary"`, `12`, `true`, `null`).
+type filterOperand struct {
+	literal any
+	path    []Key
+	isPath  bool
+}
+
+// value returns the value of the operand for the current element and false if the path does not exist.
+func (o filterOperand) value(d DefaultTraverser, current any) (any, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+
+	v, err := d.Retrieve(current, o.path)
+	if err != nil {
+		return nil, false
+	}
+
+	return d.derefResult(v), true
+}
+
+type filterComparison struct {
+	left  filterOperand
+	right filterOperand
+	op    filterOperator
+}
+
+func (f filterComparison) match(d DefaultTraverser, current any) bool {
+	l, lFound := f.left.value(d, current)
+	r, rFound := f.right.value(d, current)
+
+	// a missing path is equal only to another missing path.
+	if !lFound || !rFound {
+		equal := !lFound && !rFound
+		switch f.op {
+		case filterOperatorEqual, filterOperatorLessOrEqual, filterOperatorGreaterOrEqual:
+			return equal
+		case filterOperatorNotEqual:
+			return !equal
+		default:
+			return false
+		}
+	}
+
+	cmp, ordered, ok := compareFilterValues(d, l, r)
+	switch f.op {
+	case filterOperatorEqual:
+		return ok && cmp == 0
+	case filterOperatorNotEqual:
+		return !ok || cmp != 0
+	case filterOperatorLess:
+		return ok && ordered && cmp < 0
+	case filterOperatorLessOrEqual:
+		return ok && (cmp == 0 || ordered && cmp < 0)
+	case filterOperatorGreater:
+		return ok && ordered && cmp > 0
+	case filterOperatorGreaterOrEqual:
+		return ok && (cmp == 0 || ordered && cmp > 0)
+	}
+
+	return false
+}
+
+var (
+	filterTypeFloat64 = reflect.TypeFor[float64]()
+	filterTypeString  = reflect.TypeFor[string]()
+	filterTypeBool    = reflect.TypeFor[bool]()
+)
+
+// compareFilterValues compares two values by converting them to a common type using the key converter.
+// If any of the values is bool, both are compared as bool, else if any of them is a number both are compared as float64, else as strings.
+// It returns ok false if the values cannot be converted, and ordered false if the values can be compared only for equality.
+func compareFilterValues(d DefaultTraverser, l, r any) (cmp int, ordered bool, ok bool) {
+	if l == nil || r == nil {
+		if l == nil && r == nil {
+			return 0, false, true
+		}
+		return 0, false, false
+	}
+
+	kl, kr := reflect.TypeOf(l).Kind(), reflect.TypeOf(r).Kind()
+	switch {
+	case kl == reflect.Bool || kr == reflect.Bool:
+		lb, errL := d.keyConverter.ByType(l, filterTypeBool)
+		rb, errR := d.keyConverter.ByType(r, filterTypeBool)
+		if errL != nil || errR != nil {
+			return 0, false, false
+		}
+		if lb == rb {
+			return 0, false, true
+		}
+		return 1, false, true
+
+	case isNumberKind(kl) || isNumberKind(kr):
+		lf, errL := d.keyConverter.ByType(l, filterTypeFloat64)
+		rf, errR := d.keyConverter.ByType(r, filterTypeFloat64)
+		if errL != nil || errR != nil {
+			return 0, false, false
+		}
+		a, b := lf.(float64), rf.(float64) //nolint:forcetypeassert // ByType returns the requested type.
+		switch {
+		case a < b:
+			return -1, true, true
+		case a > b:
+			return 1, true, true
+		default:
+			return 0, true, true
+		}
+
+	case kl == reflect.String || kr == reflect.String:
+		ls, errL := d.keyConverter.ByType(l, filterTypeString)
+		rs, errR := d.keyConverter.ByType(r, filterTypeString)
+		if errL != nil || errR != nil {
+			return 0, false, false
+		}
+		return strings.Compare(ls.(string), rs.(string)), true, true //nolint:forcetypeassert // ByType returns the requested type.
+	}
+
+	if reflect.DeepEqual(l, r) {
+		return 0, false, true
+	}
+
+	return 1, false, true
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// compiledFilter holds the compiled expression of a filter key.
+type compiledFilter struct {
+	expr filterExpression
+}
+
+// filterExpr returns the compiled expression of the filter key. The expression is compiled here only if the key
+// was not created by Filter or a notation (e.g. a Key literal).
+func (s Key) filterExpr() (filterExpression, error) {
+	if s.filter != nil {
+		return s.filter.expr, nil
+	}
+
+	return compileFilter(s.Name)
+}
+
+// compileFilter parses a filter expression. The grammar is:
+//
+//	or         := and ( "||" and )*
+//	and        := unary ( "&&" unary )*
+//	unary      := "!" unary | "(" or ")" | comparison
+//	comparison := operand ( ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand )?
+//	operand    := "@" relative-path | string | number | "true" | "false" | "null"
+//
+// The relative path is written in dot notation (e.g. `@.type`, `@.tags[0]`, `@["content.type"]`) and `@` alone is the element itself.
+// An operand without comparison is an existence check and has to be a path.
+func compileFilter(expression string) (filterExpression, error) {
+	p := filterParser{src: expression}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidFilterExpression, p.src[p.pos:], p.pos)
+	}
+
+	return expr, nil
+}
+
+type filterParser struct {
+	src string
+	pos int
+}
+
+func (p *filterParser) skipBlank() {
+	p.pos = skipJSONPathBlank(p.src, p.pos)
+}
+
+// consume skips the blank characters and consumes the token if it is next.
+func (p *filterParser) consume(token string) bool {
+	p.skipBlank()
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+
+	return false
+}
+
+func (p *filterParser) parseOr() (filterExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpression, error) {
+	if p.consume("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{expr: expr}, nil
+	}
+
+	if p.consume("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidFilterExpression)
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterExpression, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range filterOperators {
+		if !p.consume(o.token) {
+			continue
+		}
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return filterComparison{left: left, right: right, op: o.op}, nil
+	}
+
+	if !left.isPath {
+		return nil, fmt.Errorf("%w: literal %v without comparison", ErrInvalidFilterExpression, left.literal)
+	}
+
+	return filterExists{path: left.path}, nil
+}
+
+func (p *filterParser) parseOperand() (filterOperand, error) {
+	p.skipBlank()
+	if p.pos >= len(p.src) {
+		return filterOperand{}, fmt.Errorf("%w: missing operand", ErrInvalidFilterExpression)
+	}
+
+	switch c := p.src[p.pos]; {
+	case c == '@':
+		start := p.pos + 1
+		end, err := scanFilterPath(p.src, start)
+		if err != nil {
+			return filterOperand{}, err
+		}
+		p.pos = end
+		path, err := dotNotationParser{}.Parse(p.src[start:end])
+		if err != nil {
+			return filterOperand{}, fmt.Errorf("%w: %w", ErrInvalidFilterExpression, err)
+		}
+		return filterOperand{path: path, isPath: true}, nil
+
+	case c == jsonPathSingleQuote || c == jsonPathDoubleQuote:
+		s, end, err := unquoteJSONPathString(p.src, p.pos)
+		if err != nil {
+			return filterOperand{}, fmt.Errorf("%w: %w", ErrInvalidFilterExpression, err)
+		}
+		p.pos = end
+		return filterOperand{literal: s}, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.src) && strings.IndexByte("+-.0123456789eE", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return filterOperand{}, fmt.Errorf("%w: %w", ErrInvalidFilterExpression, err)
+		}
+		return filterOperand{literal: f}, nil
+	}
+
+	switch {
+	case p.consume("true"):
+		return filterOperand{literal: true}, nil
+	case p.consume("false"):
+		return filterOperand{literal: false}, nil
+	case p.consume("null"):
+		return filterOperand{literal: nil}, nil
+	}
+
+	return filterOperand{}, fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidFilterExpression, p.src[p.pos:], p.pos)
+}
+
+// scanFilterPath returns the position right after the relative path that starts at `pos`.
+// The path ends at the first blank character, operator or closing parenthesis that is not inside brackets.
+func scanFilterPath(src string, pos int) (int, error) {
+	depth := 0
+	for pos < len(src) {
+		c := src[pos]
+		switch {
+		case c == jsonPathSingleQuote || c == jsonPathDoubleQuote:
+			end, err := scanQuoted(src, pos)
+			if err != nil {
+				return pos, fmt.Errorf("%w: %w", ErrInvalidFilterExpression, err)
+			}
+			pos = end
+			continue
+		case c == byte(indexSeparatorStart):
+			depth++
+		case c == byte(indexSeparatorEnd):
+			depth--
+		case depth == 0 && strings.IndexByte(" \t\n\r=!<>&|)", c) >= 0:
+			return pos, nil
+		}
+		pos++
+	}
+
+	return pos, nil
+}
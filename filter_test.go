@@ -0,0 +1,105 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileFilter(t *testing.T) {
+	tests := map[string]tst.ErrorAssertionFunc{
+		`@.type == "primary"`:                tst.NoError(),
+		`(@.a >= 1 && @.b) || !(@.c != 'x')`: tst.NoError(),
+		`@ == null`:                          tst.NoError(),
+		`@.a[0] < -1.5e2`:                    tst.NoError(),
+		`@["a.b"] == true && @.c == false`:   tst.NoError(),
+		``:                                   tst.ErrorIs(ErrInvalidFilterExpression),
+		`@.a ==`:                             tst.ErrorIs(ErrInvalidFilterExpression),
+		`"x"`:                                tst.ErrorIs(ErrInvalidFilterExpression),
+		`(@.a`:                               tst.ErrorIs(ErrInvalidFilterExpression),
+		`@.a @.b`:                            tst.ErrorIs(ErrInvalidFilterExpression),
+		`@.a == 'x`:                          tst.ErrorIs(ErrInvalidFilterExpression),
+		`@.a.. == 1`:                         tst.ErrorIs(ErrInvalidFilterExpression),
+		`@.a == yes`:                         tst.ErrorIs(ErrInvalidFilterExpression),
+		`@.a == 1-`:                          tst.ErrorIs(ErrInvalidFilterExpression),
+	}
+
+	for expression, errorAsserter := range tests {
+		t.Run(expression, func(t *testing.T) {
+			_, err := compileFilter(expression)
+			errorAsserter(t, err)
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	p, err := WrapJSON([]byte(`{
+		"items": [
+			{"type": "secondary", "value": 1, "price": "12.5"},
+			{"type": "primary", "value": 2, "price": 8, "sale": true},
+			{"type": "primary", "value": 3, "price": 20, "tags": ["a", "b"]},
+			{"value": 4, "price": null}
+		],
+		"byName": {"b": {"on": "true"}, "a": {"on": false}, "c": {"on": true}}
+	}`))
+	tst.NoError()(t, err)
+
+	tests := []struct {
+		errorAsserter tst.ErrorAssertionFunc
+		selector      string
+		expected      []int
+	}{
+		{selector: `items[?(@.type=="primary")].value`, expected: []int{2, 3}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.type != "primary")].value`, expected: []int{1, 4}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.price < 10)].value`, expected: []int{2}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.price >= 12.5)].value`, expected: []int{1, 3}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.price == null)].value`, expected: []int{4}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.value > "1" && @.value <= 3)].value`, expected: []int{2, 3}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.sale || @.tags)].value`, expected: []int{2, 3}, errorAsserter: tst.NoError()},
+		{selector: `items[?(!@.type)].value`, expected: []int{4}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.tags[-1] == 'b')].value`, expected: []int{3}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.sale == true)].value`, expected: []int{2}, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.value == "x")].value`, expected: nil, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.missing == 1)].value`, expected: nil, errorAsserter: tst.NoError()},
+		{selector: `items[?(@.type == "primary")][?(@ > 2)]`, expected: []int{8, 20, 3}, errorAsserter: tst.NoError()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.selector, func(t *testing.T) {
+			got, err := p.IntSlice(tc.selector)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	keys, err := p.Matches(`byName[?(@.on == true)]`)
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, len(keys), 2)
+	testingx.AssertEqual(t, keys[0].Path, []Key{Field("byName"), Field("b")})
+	testingx.AssertEqual(t, keys[1].Path, []Key{Field("byName"), Field("c")})
+
+	_, err = NewDefaultTraverser(NewDefaultConverter()).Retrieve(map[string]any{"a": []any{1}}, []Key{Field("a"), Filter("@ ==")})
+	tst.ErrorIs(ErrInvalidFilterExpression)(t, err)
+}
+
+func TestFilterCompiledOnce(t *testing.T) {
+	// the filters are compiled when the keys are created, not on each traversal.
+	s := MustCompile(`items[?(@.on == true)]['id', ?(@.id > 1)]`)
+	path := s.Path()
+	require.NotNil(t, path[1].filter)
+	require.NotNil(t, path[2].UnionKeys()[1].filter)
+	require.NotNil(t, Filter("@.on").filter)
+
+	p := Wrap(map[string]any{"items": []any{map[string]any{"on": true, "id": 2}, map[string]any{"on": false, "id": 3}}})
+	got, err := p.AnyCompiled(s)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, any([]any{2}))
+
+	// a Key literal is compiled during traversal.
+	literal := Key{Type: KeyTypeFilter, Name: "@.on == false"}
+	got, err = p.Path([]Key{Field("items"), literal, Field("id")})
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, any([]any{3}))
+}
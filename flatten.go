@@ -0,0 +1,66 @@
+package pick
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Flatten returns the leaf values of the wrapped data keyed by their selectors, formatted using the picker's notation (e.g. `items[0].id`).
+// Leaves are the values that are not maps, structs, slices or arrays, as well as the empty ones (so that they are not lost in a round trip).
+// If the wrapped data is a leaf itself, it is keyed by the empty selector. Values that are reached again through a cycle are omitted
+// (see Walk). See Unflatten for the inverse.
+func (p Picker) Flatten() (map[string]any, error) {
+	flat := map[string]any{}
+	err := Walk(p, func(path []Key, value Picker) WalkAction {
+		if !p.isFlattenLeaf(value.data) {
+			return WalkContinue
+		}
+		flat[p.notation.Format(path...)] = value.data
+		return WalkSkip
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return flat, nil
+}
+
+// isFlattenLeaf returns true if the value is not a collection or if it is an empty one.
+func (p Picker) isFlattenLeaf(v any) bool {
+	rv := reflect.ValueOf(indirect(v))
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Struct:
+		return len(p.structFieldsOf(rv.Type()).list) == 0
+	default:
+		return true
+	}
+}
+
+// Unflatten rebuilds the nested data from values keyed by `DotNotation` selectors (e.g. as returned by Flatten) and wraps it into a Picker.
+// The intermediate values are created as `map[string]any` (for field keys) or `[]any` (for index keys).
+func Unflatten(flat map[string]any) (Picker, error) {
+	return UnflattenWithNotation(DotNotation{}, flat)
+}
+
+// UnflattenWithNotation is like Unflatten but the selectors are parsed using the given notation, which is also used by the returned Picker.
+// The selectors are applied in sorted order, so conflicting selectors (e.g. `a` and `a.b`) fail deterministically.
+func UnflattenWithNotation(n Notation, flat map[string]any) (Picker, error) {
+	converter := NewDefaultConverter()
+	p := NewPicker(nil, NewDefaultTraverser(converter), converter, n)
+
+	selectors := make([]string, 0, len(flat))
+	for selector := range flat {
+		selectors = append(selectors, selector)
+	}
+	slices.Sort(selectors)
+
+	for _, selector := range selectors {
+		if err := p.Set(selector, flat[selector]); err != nil {
+			return Picker{}, err
+		}
+	}
+
+	return p, nil
+}
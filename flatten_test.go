@@ -0,0 +1,136 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := map[string]struct {
+		document string
+		expected map[string]any
+	}{
+		"nested": {
+			document: `{"items": [{"id": 1, "tags": ["a", "b"]}, {"id": 2, "tags": []}], "meta": {"next": null, "a.b": true}, "empty": {}}`,
+			expected: map[string]any{
+				"items[0].id":      float64(1),
+				"items[0].tags[0]": "a",
+				"items[0].tags[1]": "b",
+				"items[1].id":      float64(2),
+				"items[1].tags":    []any{},
+				"meta.next":        nil,
+				`meta["a.b"]`:      true,
+				"empty":            map[string]any{},
+			},
+		},
+		"scalar": {
+			document: `"a"`,
+			expected: map[string]any{"": "a"},
+		},
+		"root slice": {
+			document: `[1, [2]]`,
+			expected: map[string]any{"[0]": float64(1), "[1][0]": float64(2)},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := WrapJSON([]byte(tc.document))
+			require.NoError(t, err)
+
+			flat, err := p.Flatten()
+			require.NoError(t, err)
+			testingx.AssertEqual(t, flat, tc.expected)
+
+			// round trip.
+			unflattened, err := Unflatten(flat)
+			require.NoError(t, err)
+			testingx.AssertEqual(t, unflattened.Data(), p.Data())
+		})
+	}
+}
+
+func TestFlattenNotationAndStructs(t *testing.T) {
+	type item struct {
+		ID   int `json:"id"`
+		Name string
+	}
+
+	c := NewDefaultConverter()
+	p := NewPicker(map[string]any{"items": []item{{ID: 1, Name: "a"}}, "a/b": 1}, NewDefaultTraverser(c), c, JSONPointerNotation{})
+
+	flat, err := p.Flatten()
+	require.NoError(t, err)
+	testingx.AssertEqual(t, flat, map[string]any{
+		"/items/0/id":   1,
+		"/items/0/Name": "a",
+		"/a~1b":         1,
+	})
+
+	unflattened, err := UnflattenWithNotation(JSONPointerNotation{}, flat)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, unflattened.Data(), any(map[string]any{
+		"items": []any{map[string]any{"id": 1, "Name": "a"}},
+		"a/b":   1,
+	}))
+
+	id, err := unflattened.Int("/items/0/id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, id, 1)
+}
+
+func TestFlattenStructTags(t *testing.T) {
+	type Embedded struct {
+		ID int `yaml:"id"`
+	}
+	type item struct {
+		*Embedded
+		Name   string `json:"json_name" yaml:"name"`
+		hidden int
+		Skip   string `yaml:"-"`
+	}
+
+	c := NewDefaultConverter()
+	p := NewPicker(item{Embedded: &Embedded{ID: 7}, Name: "a", hidden: 1}, NewDefaultTraverser(c, WithStructTags("yaml")), c, DotNotation{})
+
+	flat, err := p.Flatten()
+	require.NoError(t, err)
+	testingx.AssertEqual(t, flat, map[string]any{"id": 7, "name": "a"})
+
+	// every flattened selector resolves back to its value using the same picker.
+	for selector, value := range flat {
+		got, err := p.Any(selector)
+		require.NoError(t, err, selector)
+		testingx.AssertEqual(t, got, value)
+	}
+
+	// a nil embedded pointer has no promoted fields to visit.
+	flat, err = p.Wrap(item{Name: "b"}).Flatten()
+	require.NoError(t, err)
+	testingx.AssertEqual(t, flat, map[string]any{"name": "b"})
+}
+
+func TestUnflatten(t *testing.T) {
+	p, err := Unflatten(map[string]any{
+		"a.b[1]":  2,
+		"a.b[0]":  1,
+		"a.c":     "x",
+		`["d.e"]`: true,
+		"f[0][1]": "y",
+	})
+	require.NoError(t, err)
+	testingx.AssertEqual(t, p.Data(), any(map[string]any{
+		"a":   map[string]any{"b": []any{1, 2}, "c": "x"},
+		"d.e": true,
+		"f":   []any{[]any{nil, "y"}},
+	}))
+
+	_, err = Unflatten(map[string]any{"a": 1, "a.b": 2})
+	tst.ErrorIs(ErrInvalidSetTarget)(t, err)
+
+	_, err = Unflatten(map[string]any{"a[": 1})
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+}
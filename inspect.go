@@ -0,0 +1,184 @@
+package pick
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Kind is the JSON kind of a value.
+type Kind int
+
+const (
+	KindMissing Kind = iota // the selector does not exist.
+	KindNull
+	KindBool
+	KindNumber
+	KindString
+	KindArray
+	KindObject
+	KindOther // a value that has no JSON equivalent (e.g. a func or a channel).
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindMissing:
+		return "missing"
+	case KindNull:
+		return "null"
+	case KindBool:
+		return "bool"
+	case KindNumber:
+		return "number"
+	case KindString:
+		return "string"
+	case KindArray:
+		return "array"
+	case KindObject:
+		return "object"
+	case KindOther:
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+// Has returns true if the selector exists in the wrapped data, even if its value is null, or if a selector that selects multiple elements
+// (e.g. `items[*].id`) matches at least one element.
+// A missing field or an index out of range is not an error, while any other error (e.g. an invalid selector) is returned.
+func (p Picker) Has(selector string) (bool, error) {
+	k, err := p.KindOf(selector)
+	return k != KindMissing, err
+}
+
+// IsNull returns true if the selector exists and its value is null (nil, or a nil pointer, map, slice or interface). See [Picker.Has].
+func (p Picker) IsNull(selector string) (bool, error) {
+	k, err := p.KindOf(selector)
+	return k == KindNull, err
+}
+
+// KindOf returns the JSON kind of the value of the selector, without converting it. Pointers are dereferenced,
+// maps and structs are objects and slices and arrays are arrays. Like encoding/json, `[]byte` values, `time.Time` values and
+// values that implement encoding.TextMarshaler are strings, while the kind of a json.Marshaler is the kind of the JSON it encodes to.
+// If the selector does not exist, KindMissing is returned without error. A selector that selects multiple elements (e.g. `items[*].id`)
+// results to an array of the matches, so it is KindMissing if nothing matches and KindArray otherwise.
+func (p Picker) KindOf(selector string) (Kind, error) {
+	path, err := p.parse(selector)
+	if err != nil {
+		return KindMissing, err
+	}
+
+	v, err := p.Path(path)
+	if err != nil {
+		if errors.Is(err, ErrFieldNotFound) {
+			return KindMissing, nil
+		}
+		return KindMissing, err
+	}
+
+	if matches, isSlice := v.([]any); isSlice && len(matches) == 0 && slices.ContainsFunc(path, Key.selectsMultiple) {
+		return KindMissing, nil
+	}
+
+	return kindOf(v), nil
+}
+
+func kindOf(v any) Kind {
+	rv := reflect.ValueOf(v)
+	for {
+		if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+			return KindNull
+		}
+		if k, is := marshalerKind(rv); is {
+			return k
+		}
+		if rv.Kind() != reflect.Pointer && rv.Kind() != reflect.Interface {
+			break
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return KindNull
+	case reflect.Bool:
+		return KindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return KindNumber
+	case reflect.String:
+		if rv.Type() == jsonNumberType {
+			return KindNumber
+		}
+		return KindString
+	case reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return KindNull
+		}
+		if rv.Kind() == reflect.Map {
+			return KindObject
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 { // []byte is encoded as a base64 string.
+			return KindString
+		}
+		return KindArray
+	case reflect.Array:
+		return KindArray
+	case reflect.Struct:
+		return KindObject
+	default:
+		return KindOther
+	}
+}
+
+// marshalerKind returns the kind of the values that encoding/json encodes using their own methods.
+func marshalerKind(rv reflect.Value) (Kind, bool) {
+	if !rv.IsValid() || !rv.CanInterface() {
+		return KindMissing, false
+	}
+
+	switch m := rv.Interface().(type) {
+	case time.Time:
+		return KindString, true
+	case json.Marshaler:
+		js, err := m.MarshalJSON()
+		if err != nil {
+			return KindOther, true
+		}
+		return kindOfJSON(js), true
+	case encoding.TextMarshaler:
+		return KindString, true
+	default:
+		return KindMissing, false
+	}
+}
+
+// kindOfJSON returns the kind of an encoded JSON value by its first character.
+func kindOfJSON(js []byte) Kind {
+	js = bytes.TrimSpace(js)
+	if len(js) == 0 {
+		return KindOther
+	}
+
+	switch js[0] {
+	case 'n':
+		return KindNull
+	case 't', 'f':
+		return KindBool
+	case '"':
+		return KindString
+	case '[':
+		return KindArray
+	case '{':
+		return KindObject
+	default:
+		return KindNumber
+	}
+}
+
+var jsonNumberType = reflect.TypeFor[json.Number]() //nolint:gochecknoglobals
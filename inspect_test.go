@@ -0,0 +1,109 @@
+package pick
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+// stringStruct is a struct that is encoded as a JSON string.
+type stringStruct struct{ value string }
+
+func (s stringStruct) MarshalJSON() ([]byte, error) { return json.Marshal(s.value) }
+
+func TestKindOf(t *testing.T) {
+	type inner struct {
+		Ptr *int
+		Map map[string]any
+	}
+	type outer struct {
+		Time      time.Time
+		Inner     *inner
+		Any       any
+		TimePtr   *time.Time
+		Raw       json.RawMessage
+		RawObject json.RawMessage
+		Bytes     []byte
+		IP        net.IP
+		Number    json.Number
+		Marshaler stringStruct
+		Arr       [2]int
+	}
+
+	jsonPicker, err := WrapJSON([]byte(`{"a": {"b": null, "c": [1, "x", true]}, "d": 1.5}`))
+	require.NoError(t, err)
+	now := time.Now()
+	structPicker := Wrap(outer{
+		Inner:     &inner{},
+		Number:    "12",
+		Time:      now,
+		TimePtr:   &now,
+		Raw:       json.RawMessage(`"x"`),
+		RawObject: json.RawMessage(` {"a": 1}`),
+		Bytes:     []byte("abc"),
+		IP:        net.IPv4(127, 0, 0, 1),
+	})
+
+	tests := map[string]struct {
+		picker   Picker
+		selector string
+		expected Kind
+		has      bool
+		isNull   bool
+	}{
+		"json object":           {picker: jsonPicker, selector: "a", expected: KindObject, has: true},
+		"json null":             {picker: jsonPicker, selector: "a.b", expected: KindNull, has: true, isNull: true},
+		"json array":            {picker: jsonPicker, selector: "a.c", expected: KindArray, has: true},
+		"json number":           {picker: jsonPicker, selector: "a.c[0]", expected: KindNumber, has: true},
+		"json string":           {picker: jsonPicker, selector: "a.c[1]", expected: KindString, has: true},
+		"json bool":             {picker: jsonPicker, selector: "a.c[2]", expected: KindBool, has: true},
+		"json missing field":    {picker: jsonPicker, selector: "a.x", expected: KindMissing},
+		"json missing index":    {picker: jsonPicker, selector: "a.c[5]", expected: KindMissing},
+		"json missing parent":   {picker: jsonPicker, selector: "x.y.z", expected: KindMissing},
+		"multiple matches":      {picker: jsonPicker, selector: "a.c[*]", expected: KindArray, has: true},
+		"multiple no matches":   {picker: jsonPicker, selector: "a.c[*].zzz", expected: KindMissing},
+		"descent no matches":    {picker: jsonPicker, selector: "..zzz", expected: KindMissing},
+		"json root":             {picker: jsonPicker, selector: "", expected: KindObject, has: true},
+		"struct pointer":        {picker: structPicker, selector: "Inner", expected: KindObject, has: true},
+		"struct nil pointer":    {picker: structPicker, selector: "Inner.Ptr", expected: KindNull, has: true, isNull: true},
+		"struct nil map":        {picker: structPicker, selector: "Inner.Map", expected: KindNull, has: true, isNull: true},
+		"struct nil interface":  {picker: structPicker, selector: "Any", expected: KindNull, has: true, isNull: true},
+		"struct json.Number":    {picker: structPicker, selector: "Number", expected: KindNumber, has: true},
+		"struct array":          {picker: structPicker, selector: "Arr", expected: KindArray, has: true},
+		"struct missing":        {picker: structPicker, selector: "Missing", expected: KindMissing},
+		"time":                  {picker: structPicker, selector: "Time", expected: KindString, has: true},
+		"time pointer":          {picker: structPicker, selector: "TimePtr", expected: KindString, has: true},
+		"json marshaler":        {picker: structPicker, selector: "Raw", expected: KindString, has: true},
+		"json marshaler object": {picker: structPicker, selector: "RawObject", expected: KindObject, has: true},
+		"json marshaler struct": {picker: structPicker, selector: "Marshaler", expected: KindString, has: true},
+		"text marshaler":        {picker: structPicker, selector: "IP", expected: KindString, has: true},
+		"bytes":                 {picker: structPicker, selector: "Bytes", expected: KindString, has: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			k, err := tc.picker.KindOf(tc.selector)
+			require.NoError(t, err)
+			testingx.AssertEqual(t, k, tc.expected)
+
+			has, err := tc.picker.Has(tc.selector)
+			require.NoError(t, err)
+			testingx.AssertEqual(t, has, tc.has)
+
+			isNull, err := tc.picker.IsNull(tc.selector)
+			require.NoError(t, err)
+			testingx.AssertEqual(t, isNull, tc.isNull)
+		})
+	}
+
+	_, err = jsonPicker.Has("a[")
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+
+	testingx.AssertEqual(t, KindObject.String(), "object")
+	testingx.AssertEqual(t, kindOf(func() {}), KindOther)
+}
@@ -0,0 +1,87 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a bounded, concurrency safe, least recently used (LRU) cache.
+type Cache[K comparable, V any] struct {
+	entries  map[K]*list.Element
+	order    *list.List // front is the most recently used.
+	capacity int
+	mu       sync.Mutex
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New creates a cache that holds up to capacity entries. A capacity less than 1 is treated as 1.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	capacity = max(capacity, 1)
+	return &Cache[K, V]{
+		entries:  make(map[K]*list.Element, capacity),
+		order:    list.New(),
+		capacity: capacity,
+	}
+}
+
+// Get returns the value of the key and marks it as the most recently used one.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, found := c.entries[key]
+	if !found {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*entry[K, V]).value, true //nolint:forcetypeassert // only entries are stored.
+}
+
+// Add adds the value of the key (evicting the least recently used entry if the cache is full) and returns it.
+// If the key is already cached (e.g. added by another goroutine in the meantime), the cached value is kept and returned instead.
+func (c *Cache[K, V]) Add(key K, value V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, found := c.entries[key]; found {
+		c.order.MoveToFront(e)
+		return e.Value.(*entry[K, V]).value //nolint:forcetypeassert // only entries are stored.
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry[K, V]).key) //nolint:forcetypeassert // only entries are stored.
+	}
+
+	return value
+}
+
+// Len returns the number of the cached entries.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Capacity returns the maximum number of entries.
+func (c *Cache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Purge removes all the entries.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.order.Init()
+}
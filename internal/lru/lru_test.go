@@ -0,0 +1,62 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	c := New[string, int](2)
+	testingx.AssertEqual(t, c.Add("a", 1), 1)
+	testingx.AssertEqual(t, c.Add("b", 2), 2)
+
+	// an existing key keeps its value.
+	testingx.AssertEqual(t, c.Add("a", 10), 1)
+
+	// "b" is the least recently used one, since "a" was touched by Add.
+	testingx.AssertEqual(t, c.Add("c", 3), 3)
+	_, found := c.Get("b")
+	require.False(t, found)
+
+	got, found := c.Get("a")
+	require.True(t, found)
+	testingx.AssertEqual(t, got, 1)
+
+	// "c" is evicted now, since "a" was touched by Get.
+	c.Add("d", 4)
+	_, found = c.Get("c")
+	require.False(t, found)
+	testingx.AssertEqual(t, c.Len(), 2)
+
+	c.Purge()
+	testingx.AssertEqual(t, c.Len(), 0)
+	_, found = c.Get("a")
+	require.False(t, found)
+
+	testingx.AssertEqual(t, New[string, int](0).Capacity(), 1)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := New[int, int](8)
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				if v, found := c.Get(j % 10); found {
+					if v != j%10 {
+						t.Errorf("expected %d, got %d", j%10, v)
+					}
+					continue
+				}
+				c.Add(j%10, j%10)
+			}
+		}()
+	}
+	wg.Wait()
+	testingx.AssertEqual(t, c.Len(), 8)
+}
@@ -0,0 +1,29 @@
+package structtag
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DefaultTags are the struct tags (in priority order) that are used by default to resolve the names of struct fields.
+var DefaultTags = []string{"json", "config"} //nolint:gochecknoglobals
+
+// FieldName returns the name of the first tag (in tags order) that defines one and true, or else the Go name of the field and false.
+// The last returned value is true if the field is omitted by the tag (`-`, e.g. `json:"-"`).
+func FieldName(f reflect.StructField, tags []string) (name string, tagged, omitted bool) {
+	for _, t := range tags {
+		tagValue, exists := f.Tag.Lookup(t)
+		if !exists {
+			continue
+		}
+		if tagValue == "-" {
+			return "", false, true
+		}
+		name, _, _ := strings.Cut(tagValue, ",")
+		if name != "" {
+			return name, true, false
+		}
+	}
+
+	return f.Name, false, false
+}
@@ -0,0 +1,43 @@
+package structtag
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moukoublen/pick/internal/testingx"
+)
+
+func TestFieldName(t *testing.T) {
+	type tagged struct {
+		Plain    string
+		JSON     string `json:"json_name,omitempty"`
+		Config   string `config:"config_name"`
+		Both     string `config:"config_both" json:"json_both"`
+		EmptyTag string `config:"config_empty" json:",omitempty"`
+		Omitted  string `json:"-"`
+	}
+
+	type result struct {
+		Name    string
+		Tagged  bool
+		Omitted bool
+	}
+
+	expected := map[string]result{
+		"Plain":    {Name: "Plain"},
+		"JSON":     {Name: "json_name", Tagged: true},
+		"Config":   {Name: "config_name", Tagged: true},
+		"Both":     {Name: "json_both", Tagged: true},
+		"EmptyTag": {Name: "config_empty", Tagged: true},
+		"Omitted":  {Omitted: true},
+	}
+
+	typ := reflect.TypeFor[tagged]()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		t.Run(f.Name, func(t *testing.T) {
+			name, isTagged, omitted := FieldName(f, DefaultTags)
+			testingx.AssertEqual(t, result{Name: name, Tagged: isTagged, Omitted: omitted}, expected[f.Name])
+		})
+	}
+}
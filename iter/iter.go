@@ -4,22 +4,34 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strings"
+	"sync"
 
 	"github.com/moukoublen/pick/internal/errorsx"
+	"github.com/moukoublen/pick/internal/structtag"
 )
 
+// Lengther is an optional interface that custom container types can implement in order to report their length to Len.
+type Lengther interface {
+	PickLen() int
+}
+
+// Iterable is an optional interface that custom collection types (e.g. sparse arrays) can implement in order to be iterated by ForEach.
+// PickEach has to call fn for each element in order, and stop (returning the error) if fn returns an error.
+type Iterable interface {
+	PickEach(fn func(index int, item any) error) error
+}
+
+// FieldIterable is an optional interface that custom types with fields (e.g. ordered maps) can implement in order to be iterated by ForEachField.
+// PickEachField has to call fn for each field, and stop (returning the error) if fn returns an error.
+type FieldIterable interface {
+	PickEachField(fn func(name string, item any) error) error
+}
+
 type FieldOpMeta struct {
 	Name   string
 	Length int
 }
 
-// defaultTags TODO: consider make them configurable in the future.
-var defaultTags = []string{ //nolint:gochecknoglobals
-	"json",
-	"config",
-}
-
 // ForEachField applies the given operation to each field of the input if it has fields
 // (struct or map), or returns ErrNoFields for types that don't have fields (arrays, slices, etc.).
 // If the operation returns a non-nil error it will cause the entire ForEachField function to terminate without applying the
@@ -27,20 +39,27 @@ var defaultTags = []string{ //nolint:gochecknoglobals
 //
 // The function first tries to handle maps of basic types directly by avoiding reflection for performance reasons.
 // If the input is not one of the directly handled types, it uses reflection to determine the input type.
-// For structs, it iterates over fields using struct tags (json, config) for field names, falling back to actual field names.
-// For maps, it iterates over key-value pairs using keys as field names. For pointers or interfaces, it dereferences
-// the input and recursively applies ForEachField to the dereferenced value.
+// For structs, it iterates over the exported fields using struct tags (json, config) for field names, falling back to actual field names.
+// Fields with a `-` tag (e.g. `json:"-"`) are skipped.
+// For maps (and *sync.Map), it iterates over key-value pairs using keys as field names. For pointers or interfaces, it dereferences
+// the input and recursively applies ForEachField to the dereferenced value. Types that implement FieldIterable are checked first.
 //
 // The function uses deferred recovery to capture and return any panic as an error.
 func ForEachField(input any, operation func(item any, meta FieldOpMeta) error) (rErr error) { //nolint:gocyclo
 	defer errorsx.RecoverPanicToError(&rErr)
 
+	if fi, is := input.(FieldIterable); is {
+		return forEachCustomField(fi, operation)
+	}
+
 	// attempt to quick return on map of basic types by avoiding reflect.
 	switch cc := input.(type) {
 	case map[string]any:
 		return forEachMap(cc, operation)
 	case map[string]string:
 		return forEachMap(cc, operation)
+	case *sync.Map:
+		return forEachSyncMap(cc, operation)
 	}
 
 	typeOfInput := reflect.TypeOf(input)
@@ -70,11 +89,20 @@ func ForEachField(input any, operation func(item any, meta FieldOpMeta) error) (
 
 	case reflect.Struct:
 		valueOfInput := reflect.ValueOf(input)
-		num := valueOfInput.NumField()
-		for i := range num {
+		// the length is the number of the fields that are passed to the operation (the exported and not omitted ones).
+		num := 0
+		for i := range valueOfInput.NumField() {
+			if _, emitted := structFieldToEmit(typeOfInput.Field(i)); emitted {
+				num++
+			}
+		}
+		for i := range valueOfInput.NumField() {
+			name, emitted := structFieldToEmit(typeOfInput.Field(i))
+			if !emitted {
+				continue
+			}
 			fieldVal := valueOfInput.Field(i)
-			fieldType := typeOfInput.Field(i)
-			err := operation(fieldVal.Interface(), FieldOpMeta{Name: structFieldName(fieldType, defaultTags), Length: num})
+			err := operation(fieldVal.Interface(), FieldOpMeta{Name: name, Length: num})
 			if err != nil {
 				return err
 			}
@@ -101,20 +129,14 @@ func ForEachField(input any, operation func(item any, meta FieldOpMeta) error) (
 	return rErr
 }
 
-// structFieldName returns the first available tag of the requested (e.g. json), or else it falls back to the actual name of the struct field.
-func structFieldName(f reflect.StructField, tags []string) string {
-	for _, t := range tags {
-		tagValue, exists := f.Tag.Lookup(t)
-		if !exists {
-			continue
-		}
-		tagValueParts := strings.Split(tagValue, ",")
-		if len(tagValueParts) > 0 && len(tagValueParts[0]) > 0 {
-			return tagValueParts[0]
-		}
+// structFieldToEmit returns the name of the field and true if the field is exported and not omitted by its tags.
+func structFieldToEmit(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
 	}
+	name, _, omitted := structtag.FieldName(f, structtag.DefaultTags)
 
-	return f.Name
+	return name, !omitted
 }
 
 // valueAsString is a tiny convert function.
@@ -149,6 +171,37 @@ func forEachMap[V any](m map[string]V, operation func(item any, meta FieldOpMeta
 	return nil
 }
 
+// forEachCustomField iterates a FieldIterable. If it does not implement Lengther, the fields are collected first in order to report the length.
+func forEachCustomField(fi FieldIterable, operation func(item any, meta FieldOpMeta) error) error {
+	if l, is := fi.(Lengther); is {
+		length := l.PickLen()
+		return fi.PickEachField(func(name string, item any) error {
+			return operation(item, FieldOpMeta{Name: name, Length: length})
+		})
+	}
+
+	type field struct {
+		item any
+		name string
+	}
+	var fields []field
+	err := fi.PickEachField(func(name string, item any) error {
+		fields = append(fields, field{name: name, item: item})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		if err := operation(f.item, FieldOpMeta{Name: f.name, Length: len(fields)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type CollectionOpMeta struct {
 	Index  int
 	Length int
@@ -162,12 +215,18 @@ type CollectionOpMeta struct {
 // The function first tries to handle slices of basic types directly by avoiding reflection for performance reasons.
 // If the input is not one of the directly handled types, it uses reflection to determine the input type and
 // iterates over elements if it is a slice or array. For pointers or interfaces, it dereferences the input and
-// applies the operation to the dereferenced value. For other types, it applies the operation directly.
+// applies the operation to the dereferenced value. Sequences (see IsSequence) are evaluated first, in order to report their length,
+// which means that a channel is received from until it is closed. For other types, it applies the operation directly.
+// Types that implement Iterable are checked first.
 //
 // The function uses deferred recovery to capture and return any panic as an error.
 func ForEach(input any, operation func(item any, meta CollectionOpMeta) error) (rErr error) { //nolint:gocyclo
 	defer errorsx.RecoverPanicToError(&rErr)
 
+	if it, is := input.(Iterable); is {
+		return forEachCustom(it, operation)
+	}
+
 	// attempt to quick return on slice of basic types by avoiding reflect.
 	switch cc := input.(type) {
 	case []any:
@@ -237,12 +296,41 @@ func ForEach(input any, operation func(item any, meta CollectionOpMeta) error) (
 		// single operation call attempt
 		return operation(el.Interface(), CollectionOpMeta{Index: 0, Length: 1})
 
+	case reflect.Func, reflect.Chan:
+		if isSequenceType(typeOfInput) {
+			return forEachSlice(sequenceElements(reflect.ValueOf(input)), operation)
+		}
+
+		// single operation call attempt
+		return operation(input, CollectionOpMeta{Index: 0, Length: 1})
+
 	default:
 		// single operation call attempt
 		return operation(input, CollectionOpMeta{Index: 0, Length: 1})
 	}
 }
 
+// forEachCustom iterates an Iterable. If it does not implement Lengther, the elements are collected first in order to report the length.
+func forEachCustom(it Iterable, operation func(item any, meta CollectionOpMeta) error) error {
+	if l, is := it.(Lengther); is {
+		length := l.PickLen()
+		return it.PickEach(func(index int, item any) error {
+			return operation(item, CollectionOpMeta{Index: index, Length: length})
+		})
+	}
+
+	var items []any
+	err := it.PickEach(func(_ int, item any) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return forEachSlice(items, operation)
+}
+
 func forEachSlice[T any](s []T, operation func(item any, meta CollectionOpMeta) error) error {
 	l := len(s)
 	for i := range s {
@@ -313,11 +401,17 @@ func MapOpFn[T any](fn func(item any) (T, error)) func(item any, meta Collection
 	}
 }
 
-// Len returns the result of built in len function if the input is of type slice, array, map, string or channel.
-// If the input is pointer and not nil, it dereferences the destination.
+// Len returns the result of built in len function if the input is of type slice, array, map, string or channel
+// (for channels this is the number of the queued elements, so none is consumed). The entries of a *sync.Map are counted
+// and an iter.Seq or iter.Seq2 is evaluated in order to count its elements.
+// If the input is pointer and not nil, it dereferences the destination. Types that implement Lengther are checked first.
 func Len(input any) (l int, rErr error) {
 	defer errorsx.RecoverPanicToError(&rErr)
 
+	if cl, is := input.(Lengther); is {
+		return cl.PickLen(), nil
+	}
+
 	// attempt to quick return on slice of basic types by avoiding reflect.
 	switch cc := input.(type) {
 	case []any:
@@ -356,6 +450,8 @@ func Len(input any) (l int, rErr error) {
 		return len(cc), nil
 	case map[string]any:
 		return len(cc), nil
+	case *sync.Map:
+		return syncMapLen(cc), nil
 	}
 
 	typeOfInput := reflect.TypeOf(input)
@@ -370,6 +466,10 @@ func Len(input any) (l int, rErr error) {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
 		valueOfInput := reflect.ValueOf(input)
 		return valueOfInput.Len(), nil
+	case reflect.Func:
+		if isSequenceType(typeOfInput) {
+			return len(sequenceElements(reflect.ValueOf(input))), nil
+		}
 	case reflect.Pointer, reflect.Interface:
 		valueOfInput := reflect.ValueOf(input)
 		if valueOfInput.IsNil() {
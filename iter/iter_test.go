@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/ifnotnil/x/tst"
@@ -64,6 +65,12 @@ func TestIterForEachField(t *testing.T) {
 		B int    `json:"b"`
 	}
 
+	type FooPrivate struct {
+		A string
+		b int
+		C string `json:"-"`
+	}
+
 	type stringAlias string
 
 	tests := map[string]struct {
@@ -197,6 +204,17 @@ func TestIterForEachField(t *testing.T) {
 				},
 			},
 		},
+		"struct FooPrivate": {
+			Input:         FooPrivate{A: "a", b: 1, C: "c"},
+			ErrorAsserter: tst.NoError(),
+			ExpectedCalls: []expectedOpCall[FieldOpMeta]{
+				{
+					Meta:        FieldOpMeta{Name: "A", Length: 1},
+					Item:        "a",
+					ReturnError: nil,
+				},
+			},
+		},
 		"struct Foo error": {
 			Input:         Foo{A: "a", B: 1},
 			ErrorAsserter: tst.ErrorIs(mockError),
@@ -866,3 +884,203 @@ func BenchmarkValueAsString(b *testing.B) {
 }
 
 func ptr[T any](x T) *T { return &x }
+
+// sparseArray is a custom collection that implements Iterable.
+type sparseArray struct {
+	values map[int]any
+	length int
+}
+
+func (s sparseArray) PickEach(fn func(index int, item any) error) error {
+	for i := range s.length {
+		if err := fn(i, s.values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countedSparseArray also implements Lengther.
+type countedSparseArray struct{ sparseArray }
+
+func (s countedSparseArray) PickLen() int { return s.length }
+
+// orderedMap is a custom type with fields that implements FieldIterable.
+type orderedMap struct {
+	values map[string]any
+	keys   []string
+}
+
+func (o orderedMap) PickEachField(fn func(name string, item any) error) error {
+	for _, k := range o.keys {
+		if err := fn(k, o.values[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCustomContainers(t *testing.T) {
+	mockError := errors.New("error")
+	sparse := sparseArray{values: map[int]any{1: "b"}, length: 3}
+	om := orderedMap{keys: []string{"z", "a"}, values: map[string]any{"a": 1, "z": 2}}
+
+	t.Run("ForEach Iterable", func(t *testing.T) {
+		for _, input := range []any{sparse, countedSparseArray{sparse}, &sparse} {
+			m := &MockOp[CollectionOpMeta]{}
+			m.Test(t)
+			m.init(generateExpectedCalls([]any{nil, "b", nil}), true)
+			tst.NoError()(t, ForEach(input, m.Operation))
+			m.AssertExpectations(t)
+		}
+	})
+
+	t.Run("ForEach Iterable error", func(t *testing.T) {
+		err := ForEach(countedSparseArray{sparse}, func(any, CollectionOpMeta) error { return mockError })
+		tst.ErrorIs(mockError)(t, err)
+	})
+
+	t.Run("Map Iterable", func(t *testing.T) {
+		got, err := Map(sparse, MapOpFn(func(item any) (any, error) { return item, nil }))
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, got, []any{nil, "b", nil})
+	})
+
+	t.Run("ForEachField FieldIterable", func(t *testing.T) {
+		m := &MockOp[FieldOpMeta]{}
+		m.Test(t)
+		m.init([]expectedOpCall[FieldOpMeta]{
+			{Meta: FieldOpMeta{Name: "z", Length: 2}, Item: 2},
+			{Meta: FieldOpMeta{Name: "a", Length: 2}, Item: 1},
+		}, true)
+		tst.NoError()(t, ForEachField(om, m.Operation))
+		m.AssertExpectations(t)
+	})
+
+	t.Run("Len Lengther", func(t *testing.T) {
+		got, err := Len(countedSparseArray{sparse})
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, got, 3)
+	})
+}
+
+func TestSequences(t *testing.T) {
+	seq := func(yield func(string) bool) {
+		for _, s := range []string{"a", "b", "c"} {
+			if !yield(s) {
+				return
+			}
+		}
+	}
+	seq2 := func(yield func(string, int) bool) {
+		_ = yield("one", 1) && yield("two", 2)
+	}
+	newChan := func() <-chan int {
+		c := make(chan int, 3)
+		c <- 1
+		c <- 2
+		c <- 3
+		close(c)
+		return c
+	}
+
+	t.Run("IsSequence", func(t *testing.T) {
+		testingx.AssertEqual(t, IsSequence(seq), true)
+		testingx.AssertEqual(t, IsSequence(seq2), true)
+		testingx.AssertEqual(t, IsSequence(newChan()), true)
+		testingx.AssertEqual(t, IsSequence(make(chan int)), false)
+		testingx.AssertEqual(t, IsSequence(func() {}), false)
+		testingx.AssertEqual(t, IsSequence(func(func(string)) {}), false)
+		testingx.AssertEqual(t, IsSequence([]int{}), false)
+		testingx.AssertEqual(t, IsSequence(nil), false)
+	})
+
+	t.Run("ForEach", func(t *testing.T) {
+		for _, tc := range []struct {
+			input    any
+			expected []any
+		}{
+			{input: seq, expected: []any{"a", "b", "c"}},
+			{input: seq2, expected: []any{1, 2}},
+			{input: newChan(), expected: []any{1, 2, 3}},
+		} {
+			m := &MockOp[CollectionOpMeta]{}
+			m.Test(t)
+			m.init(generateExpectedCalls(tc.expected), true)
+			tst.NoError()(t, ForEach(tc.input, m.Operation))
+			m.AssertExpectations(t)
+		}
+	})
+
+	t.Run("Len", func(t *testing.T) {
+		l, err := Len(seq)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, l, 3)
+
+		l, err = Len(seq2)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, l, 2)
+	})
+
+	t.Run("Nth evaluates only up to the element", func(t *testing.T) {
+		evaluated := 0
+		counting := func(yield func(int) bool) {
+			for i := range 10 {
+				evaluated++
+				if !yield(i * 10) {
+					return
+				}
+			}
+		}
+
+		got, found, err := Nth(counting, 2)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, found, true)
+		testingx.AssertEqual(t, got, 20)
+		testingx.AssertEqual(t, evaluated, 3)
+
+		got, found, err = Nth(counting, -1)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, found, true)
+		testingx.AssertEqual(t, got, 90)
+
+		_, found, err = Nth(counting, 10)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, found, false)
+
+		c := newChan()
+		got, found, err = Nth(c, 1)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, found, true)
+		testingx.AssertEqual(t, got, 2)
+		testingx.AssertEqual(t, len(c), 1) // the elements up to the index are consumed.
+
+		_, found, err = Nth([]int{1}, 0)
+		tst.NoError()(t, err)
+		testingx.AssertEqual(t, found, false)
+	})
+
+	t.Run("Nth panic", func(t *testing.T) {
+		_, _, err := Nth(func(func(int) bool) { panic("panic") }, 0)
+		tst.ErrorStringContains(`recovered panic: "panic"`)(t, err)
+	})
+}
+
+func TestSyncMap(t *testing.T) {
+	m := &sync.Map{}
+	m.Store("one", 1)
+	m.Store("two", 2)
+
+	mo := &MockOp[FieldOpMeta]{}
+	mo.Test(t)
+	mo.init([]expectedOpCall[FieldOpMeta]{
+		{Meta: FieldOpMeta{Name: "one", Length: 2}, Item: 1},
+		{Meta: FieldOpMeta{Name: "two", Length: 2}, Item: 2},
+	}, false)
+	tst.NoError()(t, ForEachField(m, mo.Operation))
+	mo.AssertExpectations(t)
+
+	l, err := Len(m)
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, l, 2)
+}
@@ -0,0 +1,123 @@
+package iter
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/moukoublen/pick/internal/errorsx"
+)
+
+// IsSequence returns true if the input is a lazily evaluated collection: an iter.Seq, an iter.Seq2 (of any type)
+// or a receive-only channel. The elements of an iter.Seq2 are its values (the keys are ignored).
+func IsSequence(input any) bool {
+	if input == nil {
+		return false
+	}
+
+	return isSequenceType(reflect.TypeOf(input))
+}
+
+func isSequenceType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan:
+		return t.ChanDir() == reflect.RecvDir
+
+	case reflect.Func:
+		if t.NumIn() != 1 || t.NumOut() != 0 || t.IsVariadic() {
+			return false
+		}
+		yield := t.In(0)
+		return yield.Kind() == reflect.Func &&
+			(yield.NumIn() == 1 || yield.NumIn() == 2) &&
+			yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool &&
+			!yield.IsVariadic()
+
+	default:
+		return false
+	}
+}
+
+// eachInSequence calls fn for each element of the sequence, until fn returns false.
+// Each call evaluates the sequence again, while the elements of a channel are consumed.
+func eachInSequence(v reflect.Value, fn func(index int, item any) bool) {
+	i := 0
+	if v.Kind() == reflect.Func && v.Type().In(0).NumIn() == 2 {
+		for _, item := range v.Seq2() {
+			if !fn(i, item.Interface()) {
+				return
+			}
+			i++
+		}
+		return
+	}
+
+	for item := range v.Seq() {
+		if !fn(i, item.Interface()) {
+			return
+		}
+		i++
+	}
+}
+
+// sequenceElements evaluates the whole sequence and returns its elements.
+func sequenceElements(v reflect.Value) []any {
+	var elements []any
+	eachInSequence(v, func(_ int, item any) bool {
+		elements = append(elements, item)
+		return true
+	})
+
+	return elements
+}
+
+// Nth returns the element at the index n of a sequence (see IsSequence), evaluating (or receiving from a channel) only up to that element.
+// It returns false if the input is not a sequence or if the sequence has fewer elements. A negative index counts from the end of the sequence,
+// which requires evaluating the whole sequence.
+func Nth(input any, n int) (item any, found bool, rErr error) {
+	defer errorsx.RecoverPanicToError(&rErr)
+
+	if !IsSequence(input) {
+		return nil, false, nil
+	}
+
+	v := reflect.ValueOf(input)
+	if n < 0 {
+		elements := sequenceElements(v)
+		if n += len(elements); n < 0 {
+			return nil, false, nil
+		}
+		return elements[n], true, nil
+	}
+
+	eachInSequence(v, func(index int, element any) bool {
+		if index == n {
+			item, found = element, true
+			return false
+		}
+		return true
+	})
+
+	return item, found, nil
+}
+
+// syncMapLen counts the entries of the sync.Map.
+func syncMapLen(m *sync.Map) int {
+	l := 0
+	m.Range(func(_, _ any) bool {
+		l++
+		return true
+	})
+
+	return l
+}
+
+func forEachSyncMap(m *sync.Map, operation func(item any, meta FieldOpMeta) error) error {
+	l := syncMapLen(m)
+	var err error
+	m.Range(func(k, v any) bool {
+		err = operation(v, FieldOpMeta{Name: valueAsString(reflect.ValueOf(k)), Length: l})
+		return err == nil
+	})
+
+	return err
+}
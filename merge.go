@@ -0,0 +1,218 @@
+package pick
+
+import (
+	"reflect"
+
+	"github.com/moukoublen/pick/iter"
+)
+
+// SliceMergeStrategy defines how Merge combines two slices (arrays) found in the same path of the base and the overlay.
+type SliceMergeStrategy int
+
+const (
+	// SliceMergeReplace replaces the base slice with the overlay slice (RFC 7396 semantics).
+	SliceMergeReplace SliceMergeStrategy = iota
+	// SliceMergeAppend appends the overlay elements to the base elements.
+	SliceMergeAppend
+	// SliceMergeByIndex merges the elements that have the same index, and keeps the remaining elements of the longest slice.
+	SliceMergeByIndex
+	// SliceMergeByKey merges the object elements that have equal values in the key field (see WithSliceMergeKey).
+	// The overlay elements that do not match a base element are appended.
+	SliceMergeByKey
+)
+
+type mergeOptions struct {
+	base          Picker // resolves the fields of the structs of the base.
+	overlay       Picker // resolves the fields of the structs of the overlay.
+	sliceKey      string
+	sliceStrategy SliceMergeStrategy
+}
+
+type MergeOption func(o *mergeOptions)
+
+// WithSliceMergeStrategy sets the strategy that is used to merge slices. The default is SliceMergeReplace.
+func WithSliceMergeStrategy(s SliceMergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.sliceStrategy = s
+	}
+}
+
+// WithSliceMergeKey sets the SliceMergeByKey strategy using the given field as the key of the elements.
+func WithSliceMergeKey(field string) MergeOption {
+	return func(o *mergeOptions) {
+		o.sliceStrategy = SliceMergeByKey
+		o.sliceKey = field
+	}
+}
+
+// Merge merges the overlay data into the base data, following the JSON Merge Patch (RFC 7396) semantics by default:
+// objects (maps and structs) are merged recursively, a null overlay member removes the member from the base and
+// every other overlay value replaces the base value. The way slices are merged can be changed using WithSliceMergeStrategy or WithSliceMergeKey,
+// and the strategy applies to the slices in every depth.
+//
+// Neither base nor overlay are modified. The merged objects are returned as `map[string]any`, while every other value is a deep copy.
+// The result is wrapped using the base picker (so it keeps the traverser, converter and notation of the base).
+func Merge(base, overlay Picker, opts ...MergeOption) (Picker, error) {
+	o := mergeOptions{base: base, overlay: overlay}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	merged, err := o.merge(base.data, overlay.data)
+	if err != nil {
+		return Picker{}, err
+	}
+
+	return base.Wrap(merged), nil
+}
+
+func (o mergeOptions) merge(base, overlay any) (any, error) {
+	if isMergeObject(overlay) {
+		return o.mergeObjects(base, overlay)
+	}
+
+	if o.sliceStrategy != SliceMergeReplace && isMergeSlice(base) && isMergeSlice(overlay) {
+		return o.mergeSlices(base, overlay)
+	}
+
+	return cloneValue(overlay), nil
+}
+
+// mergeObjects merges the members of the overlay into the members of the base. If base is not an object, it is treated as an empty one.
+func (o mergeOptions) mergeObjects(base, overlay any) (any, error) {
+	merged := map[string]any{}
+	if isMergeObject(base) {
+		err := o.base.eachField(base, func(item any, meta iter.FieldOpMeta) error {
+			merged[meta.Name] = cloneValue(item)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := o.overlay.eachField(overlay, func(item any, meta iter.FieldOpMeta) error {
+		if item == nil {
+			delete(merged, meta.Name)
+			return nil
+		}
+
+		m, err := o.merge(merged[meta.Name], item)
+		if err != nil {
+			return err
+		}
+		merged[meta.Name] = m
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+func (o mergeOptions) mergeSlices(base, overlay any) (any, error) {
+	asAny := iter.MapOpFn(func(item any) (any, error) { return item, nil })
+	baseElements, err := iter.Map(cloneValue(base), asAny)
+	if err != nil {
+		return nil, err
+	}
+	overlayElements, err := iter.Map(overlay, asAny)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.sliceStrategy {
+	case SliceMergeAppend:
+		for _, e := range overlayElements {
+			baseElements = append(baseElements, cloneValue(e))
+		}
+
+	case SliceMergeByIndex:
+		for i, e := range overlayElements {
+			if i >= len(baseElements) {
+				baseElements = append(baseElements, cloneValue(e))
+				continue
+			}
+			m, err := o.merge(baseElements[i], e)
+			if err != nil {
+				return nil, err
+			}
+			baseElements[i] = m
+		}
+
+	case SliceMergeByKey:
+		for _, e := range overlayElements {
+			i := o.indexByKey(baseElements, e)
+			if i < 0 {
+				baseElements = append(baseElements, cloneValue(e))
+				continue
+			}
+			m, err := o.merge(baseElements[i], e)
+			if err != nil {
+				return nil, err
+			}
+			baseElements[i] = m
+		}
+
+	case SliceMergeReplace:
+		return cloneValue(overlay), nil
+	}
+
+	return baseElements, nil
+}
+
+// indexByKey returns the index of the first element that has the same key value as the given element, or -1 if there is none.
+func (o mergeOptions) indexByKey(elements []any, element any) int {
+	key, found := mergeObjectKey(o.overlay, element, o.sliceKey)
+	if !found {
+		return -1
+	}
+
+	for i, e := range elements {
+		if k, found := mergeObjectKey(o.base, e, o.sliceKey); found && valuesEqual(k, key) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// mergeObjectKey returns the value of the field of an object, as it is resolved by the picker.
+func mergeObjectKey(p Picker, object any, field string) (any, bool) {
+	if !isMergeObject(object) {
+		return nil, false
+	}
+
+	var value any
+	var found bool
+	_ = p.eachField(object, func(item any, meta iter.FieldOpMeta) error {
+		if meta.Name == field {
+			value, found = item, true
+		}
+		return nil
+	})
+
+	return value, found
+}
+
+func isMergeObject(v any) bool {
+	k := indirectKind(v)
+	return k == reflect.Map || k == reflect.Struct
+}
+
+func isMergeSlice(v any) bool {
+	k := indirectKind(v)
+	return k == reflect.Slice || k == reflect.Array
+}
+
+// indirectKind returns the kind of the value, dereferencing (non nil) pointers.
+func indirectKind(v any) reflect.Kind {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	return rv.Kind()
+}
@@ -0,0 +1,124 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	tests := map[string]struct {
+		base     string
+		overlay  string
+		expected string
+		opts     []MergeOption
+	}{
+		// RFC 7396 Appendix A.
+		"replace member":        {base: `{"a":"b"}`, overlay: `{"a":"c"}`, expected: `{"a":"c"}`},
+		"add member":            {base: `{"a":"b"}`, overlay: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		"remove member":         {base: `{"a":"b"}`, overlay: `{"a":null}`, expected: `{}`},
+		"remove one member":     {base: `{"a":"b","b":"c"}`, overlay: `{"a":null}`, expected: `{"b":"c"}`},
+		"replace array":         {base: `{"a":["b"]}`, overlay: `{"a":"c"}`, expected: `{"a":"c"}`},
+		"replace with array":    {base: `{"a":"c"}`, overlay: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		"nested":                {base: `{"a":{"b":"c"}}`, overlay: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		"array of objects":      {base: `{"a":[{"b":"c"}]}`, overlay: `{"a":[1]}`, expected: `{"a":[1]}`},
+		"arrays":                {base: `["a","b"]`, overlay: `["c","d"]`, expected: `["c","d"]`},
+		"object replaces array": {base: `{"a":"b"}`, overlay: `["c"]`, expected: `["c"]`},
+		"null replaces":         {base: `{"a":"foo"}`, overlay: `null`, expected: `null`},
+		"string replaces":       {base: `{"a":"foo"}`, overlay: `"bar"`, expected: `"bar"`},
+		"object into scalar":    {base: `{"e":null}`, overlay: `{"a":1}`, expected: `{"e":null,"a":1}`},
+		"object into array":     {base: `[1,2]`, overlay: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+		"null member is pruned": {base: `{}`, overlay: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+
+		// slice strategies.
+		"append": {
+			base:     `{"a":[1,2],"b":{"c":[3]}}`,
+			overlay:  `{"a":[3],"b":{"c":[4]}}`,
+			expected: `{"a":[1,2,3],"b":{"c":[3,4]}}`,
+			opts:     []MergeOption{WithSliceMergeStrategy(SliceMergeAppend)},
+		},
+		"by index": {
+			base:     `{"a":[{"x":1,"y":1},2]}`,
+			overlay:  `{"a":[{"y":2},null,3]}`,
+			expected: `{"a":[{"x":1,"y":2},null,3]}`,
+			opts:     []MergeOption{WithSliceMergeStrategy(SliceMergeByIndex)},
+		},
+		"by index shorter overlay": {
+			base:     `[1,2,3]`,
+			overlay:  `[4]`,
+			expected: `[4,2,3]`,
+			opts:     []MergeOption{WithSliceMergeStrategy(SliceMergeByIndex)},
+		},
+		"by key": {
+			base:     `{"servers":[{"name":"a","port":80,"tags":["x"]},{"name":"b","port":81},{"port":82}]}`,
+			overlay:  `{"servers":[{"name":"b","port":8081},{"name":"c","port":83},{"name":"a","tags":["y"],"port":null}]}`,
+			expected: `{"servers":[{"name":"a","tags":["x","y"]},{"name":"b","port":8081},{"port":82},{"name":"c","port":83}]}`,
+			opts:     []MergeOption{WithSliceMergeKey("name")},
+		},
+		"by key with numeric keys": {
+			base:     `[{"id":1,"v":"a"}]`,
+			overlay:  `[{"id":1.0,"v":"b"},"scalar"]`,
+			expected: `[{"id":1,"v":"b"},"scalar"]`,
+			opts:     []MergeOption{WithSliceMergeKey("id")},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			base, err := WrapJSON([]byte(tc.base))
+			require.NoError(t, err)
+			overlay, err := WrapJSON([]byte(tc.overlay))
+			require.NoError(t, err)
+			expected, err := WrapJSON([]byte(tc.expected))
+			require.NoError(t, err)
+
+			baseBefore := cloneValue(base.Data())
+			overlayBefore := cloneValue(overlay.Data())
+
+			merged, err := Merge(base, overlay, tc.opts...)
+			tst.NoError()(t, err)
+			testingx.AssertEqual(t, merged.Data(), expected.Data())
+
+			// neither base nor overlay are modified.
+			testingx.AssertEqual(t, base.Data(), baseBefore)
+			testingx.AssertEqual(t, overlay.Data(), overlayBefore)
+		})
+	}
+}
+
+func TestMergeLayers(t *testing.T) {
+	type tls struct {
+		Cert string `json:"cert"`
+	}
+	type server struct {
+		TLS  *tls           `json:"tls"`
+		Env  map[string]any `json:"env"`
+		Host string         `json:"host"`
+	}
+
+	defaults := Wrap(map[string]any{
+		"host": "localhost",
+		"port": 8080,
+		"env":  map[string]any{"LOG": "info"},
+	})
+	file := Wrap(server{Host: "example.com", TLS: &tls{Cert: "cert.pem"}, Env: map[string]any{"LOG": nil, "MODE": "prod"}})
+	env := Wrap(map[string]string{"port": "9090"})
+
+	merged, err := Merge(defaults, file)
+	require.NoError(t, err)
+	merged, err = Merge(merged, env)
+	require.NoError(t, err)
+
+	testingx.AssertEqual(t, merged.Data(), any(map[string]any{
+		"host": "example.com",
+		"port": "9090",
+		"tls":  map[string]any{"cert": "cert.pem"},
+		"env":  map[string]any{"MODE": "prod"},
+	}))
+
+	port, err := merged.Int("port")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, port, 9090)
+}
@@ -15,6 +15,11 @@ const (
 	KeyTypeUnknown KeyType = iota
 	KeyTypeField
 	KeyTypeIndex
+	KeyTypeWildcard
+	KeyTypeRecursiveDescent
+	KeyTypeSlice
+	KeyTypeFilter
+	KeyTypeUnion
 )
 
 func (s KeyType) String() string {
@@ -25,22 +30,34 @@ func (s KeyType) String() string {
 		return "field"
 	case KeyTypeIndex:
 		return "index"
+	case KeyTypeWildcard:
+		return "wildcard"
+	case KeyTypeRecursiveDescent:
+		return "recursive descent"
+	case KeyTypeSlice:
+		return "slice"
+	case KeyTypeFilter:
+		return "filter"
+	case KeyTypeUnion:
+		return "union"
 	default:
 		return ""
 	}
 }
 
+// Key is a single step of a path. Key is comparable, but since the bounds of slice keys, the members of union keys and the compiled
+// expression of filter keys are held behind pointers, == compares their identity rather than their content. Use Equal in order to compare keys.
 type Key struct {
-	Name  string
-	Index int
-	Type  KeyType
+	Slice  *SliceBounds    // only for KeyTypeSlice
+	Keys   *[]Key          // only for KeyTypeUnion (behind a pointer, so that Key stays comparable)
+	filter *compiledFilter // only for KeyTypeFilter, compiled once by Filter or the notations (nil if the expression is invalid)
+	Name   string          // the field name, or the expression for KeyTypeFilter
+	Index  int
+	Type   KeyType
 }
 
 func (s Key) calculateIndex(length int) (int, error) {
-	i := s.Index
-	if i < 0 {
-		i = length + i
-	}
+	i := relativeIndex(s.Index, length)
 
 	if i >= length {
 		return i, ErrIndexOutOfRange
@@ -49,8 +66,129 @@ func (s Key) calculateIndex(length int) (int, error) {
 	return i, nil
 }
 
-func (s Key) IsIndex() bool { return s.Type == KeyTypeIndex }
-func (s Key) IsField() bool { return s.Type == KeyTypeField }
+// relativeIndex resolves a negative index as relative to the end (e.g. -1 is the last element).
+func relativeIndex(i, length int) int {
+	if i < 0 {
+		return length + i
+	}
+
+	return i
+}
+
+// SliceBounds holds the `start:end:step` of a slice key.
+// A nil Start or End means that the bound is omitted. Negative Start or End is relative to the end (e.g. -1 is the last element).
+// Step 0 is treated as 1. Negative Step selects the elements in reverse order.
+type SliceBounds struct {
+	Start *int
+	End   *int
+	Step  int
+}
+
+// Equal reports whether the bounds are the same.
+func (b SliceBounds) Equal(o SliceBounds) bool {
+	return b.Step == o.Step && equalIntPointers(b.Start, o.Start) && equalIntPointers(b.End, o.End)
+}
+
+func equalIntPointers(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// Indices returns the indices that are selected by the slice bounds for the given length.
+func (b SliceBounds) Indices(length int) []int {
+	step := b.Step
+	if step == 0 {
+		step = 1
+	}
+
+	bound := func(p *int, def, lower, upper int) int {
+		if p == nil {
+			return def
+		}
+		return min(max(relativeIndex(*p, length), lower), upper)
+	}
+
+	var indices []int
+	if step > 0 {
+		start := bound(b.Start, 0, 0, length)
+		end := bound(b.End, length, 0, length)
+		for i := start; i < end; i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		start := bound(b.Start, length-1, -1, length-1)
+		end := bound(b.End, -1, -1, length-1)
+		for i := start; i > end; i += step {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+func (b SliceBounds) String() string {
+	sb := strings.Builder{}
+	if b.Start != nil {
+		sb.WriteString(strconv.Itoa(*b.Start))
+	}
+	sb.WriteRune(sliceSeparator)
+	if b.End != nil {
+		sb.WriteString(strconv.Itoa(*b.End))
+	}
+	if b.Step != 0 {
+		sb.WriteRune(sliceSeparator)
+		sb.WriteString(strconv.Itoa(b.Step))
+	}
+
+	return sb.String()
+}
+
+func (s Key) IsIndex() bool            { return s.Type == KeyTypeIndex }
+func (s Key) IsField() bool            { return s.Type == KeyTypeField }
+func (s Key) IsWildcard() bool         { return s.Type == KeyTypeWildcard }
+func (s Key) IsRecursiveDescent() bool { return s.Type == KeyTypeRecursiveDescent }
+func (s Key) IsSlice() bool            { return s.Type == KeyTypeSlice }
+func (s Key) IsFilter() bool           { return s.Type == KeyTypeFilter }
+func (s Key) IsUnion() bool            { return s.Type == KeyTypeUnion }
+
+// UnionKeys returns the member keys of a union key, or nil for any other key.
+func (s Key) UnionKeys() []Key {
+	if s.Keys == nil {
+		return nil
+	}
+	return *s.Keys
+}
+
+// Equal reports whether the keys are of the same type and have the same content (including the slice bounds and the union members).
+func (s Key) Equal(o Key) bool {
+	if s.Type != o.Type || s.Name != o.Name || s.Index != o.Index {
+		return false
+	}
+
+	switch {
+	case s.Slice != nil && o.Slice != nil:
+		if !s.Slice.Equal(*o.Slice) {
+			return false
+		}
+	case s.Slice != o.Slice: // only one of them is nil.
+		return false
+	}
+
+	return slices.EqualFunc(s.UnionKeys(), o.UnionKeys(), Key.Equal)
+}
+
+// selectsMultiple reports whether the key may select more than one element (e.g. wildcard).
+func (s Key) selectsMultiple() bool {
+	switch s.Type {
+	case KeyTypeWildcard, KeyTypeRecursiveDescent, KeyTypeSlice, KeyTypeFilter, KeyTypeUnion:
+		return true
+	default:
+		return false
+	}
+}
 
 func (s Key) Any() any {
 	switch s.Type {
@@ -79,10 +217,70 @@ func Index(idx int) Key {
 	}
 }
 
+// Wildcard returns a key that selects every element of a slice/array or every field of a map/struct.
+func Wildcard() Key {
+	return Key{
+		Name:  "",
+		Index: 0,
+		Type:  KeyTypeWildcard,
+	}
+}
+
+// Slice returns a key that selects the elements of a slice/array from start (inclusive) to end (exclusive) every step elements.
+// A nil start or end means that the bound is omitted (e.g. `Slice(nil, nil, 2)` is `[::2]`). See SliceBounds.
+func Slice(start, end *int, step int) Key {
+	return Key{
+		Slice: &SliceBounds{Start: start, End: end, Step: step},
+		Type:  KeyTypeSlice,
+	}
+}
+
+// Filter returns a key that selects every element of a slice/array or every field of a map/struct that matches the filter expression
+// (e.g. `Filter("@.type == 'primary'")` is `[?@.type == 'primary']`). See the filter expression syntax at doc/architecture.md.
+// The expression is compiled once, here, and an invalid expression results to an error during traversal.
+func Filter(expression string) Key {
+	k := Key{
+		Name:  expression,
+		Index: 0,
+		Type:  KeyTypeFilter,
+	}
+	if expr, err := compileFilter(expression); err == nil {
+		k.filter = &compiledFilter{expr: expr}
+	}
+
+	return k
+}
+
+// Union returns a key that selects each one of the keys, in order (e.g. `Union(Field("name"), Field("email"))` is `["name","email"]`).
+// The keys can be fields, indices, slices, filters or wildcards.
+func Union(keys ...Key) Key {
+	return Key{
+		Keys: &keys,
+		Type: KeyTypeUnion,
+	}
+}
+
+// RecursiveDescent returns a key that selects the element itself and all of its descendants (depth-first).
+// It is usually followed by another key, e.g. `..id` is the path `[]Key{RecursiveDescent(), Field("id")}`.
+func RecursiveDescent() Key {
+	return Key{
+		Name:  "",
+		Index: 0,
+		Type:  KeyTypeRecursiveDescent,
+	}
+}
+
 const (
 	fieldSeparator      rune = '.'
 	indexSeparatorStart rune = '['
 	indexSeparatorEnd   rune = ']'
+	escapeCharacter     rune = '\\'
+	wildcard            rune = '*'
+	sliceSeparator      rune = ':'
+	filterStart         rune = '?'
+	unionSeparator      rune = ','
+	doubleQuote         rune = '"'
+	singleQuote         rune = '\''
 )
 
 type DotNotation struct {
@@ -96,17 +294,47 @@ func (d dotNotationFormatter) formatKey(k Key) string {
 	switch k.Type {
 	case KeyTypeIndex:
 		return fmt.Sprintf("[%d]", k.Index)
+	case KeyTypeWildcard:
+		return "[*]"
+	case KeyTypeRecursiveDescent:
+		return ".."
+	case KeyTypeSlice:
+		if k.Slice == nil {
+			return "[:]"
+		}
+		return "[" + k.Slice.String() + "]"
+	case KeyTypeFilter:
+		return "[?" + k.Name + "]"
+	case KeyTypeUnion:
+		members := make([]string, len(k.UnionKeys()))
+		for i, m := range k.UnionKeys() {
+			members[i] = d.formatUnionMember(m)
+		}
+		return "[" + strings.Join(members, string(unionSeparator)) + "]"
 	case KeyTypeField:
+		if d.needsQuoting(k.Name) {
+			return d.quote(k.Name)
+		}
 		return k.Name
 	default:
 		return ""
 	}
 }
 
+// formatUnionMember formats a key as a member of a union, which is the format of the key inside the brackets.
+func (d dotNotationFormatter) formatUnionMember(k Key) string {
+	if k.IsField() {
+		return d.quoteString(k.Name)
+	}
+
+	f := d.formatKey(k)
+	return strings.TrimSuffix(strings.TrimPrefix(f, string(indexSeparatorStart)), string(indexSeparatorEnd))
+}
+
 func (d dotNotationFormatter) Format(path ...Key) string {
 	sb := strings.Builder{}
 	for i, c := range path {
-		if i > 0 && c.IsField() {
+		if i > 0 && c.IsField() && !d.needsQuoting(c.Name) && !path[i-1].IsRecursiveDescent() {
 			sb.WriteRune(fieldSeparator)
 		}
 		sb.WriteString(d.formatKey(c))
@@ -115,6 +343,42 @@ func (d dotNotationFormatter) Format(path ...Key) string {
 	return sb.String()
 }
 
+// needsQuoting reports whether a field name has to be formatted in the quoted form (`["name"]`) in order to be parsed back as is.
+func (d dotNotationFormatter) needsQuoting(name string) bool {
+	if name == "" || name == string(wildcard) {
+		return true
+	}
+
+	return strings.ContainsFunc(name, func(r rune) bool {
+		switch r {
+		case fieldSeparator, indexSeparatorStart, indexSeparatorEnd, escapeCharacter, doubleQuote, singleQuote:
+			return true
+		}
+		return false
+	})
+}
+
+// quote formats the name in the quoted form (`["name"]`), escaping double quotes and backslashes.
+func (d dotNotationFormatter) quote(name string) string {
+	return string(indexSeparatorStart) + d.quoteString(name) + string(indexSeparatorEnd)
+}
+
+// quoteString formats the name as a double quoted string (`"name"`), escaping double quotes and backslashes.
+func (d dotNotationFormatter) quoteString(name string) string {
+	sb := strings.Builder{}
+	sb.Grow(len(name) + 2) //nolint:mnd
+	sb.WriteRune(doubleQuote)
+	for _, r := range name {
+		if r == doubleQuote || r == escapeCharacter {
+			sb.WriteRune(escapeCharacter)
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteRune(doubleQuote)
+
+	return sb.String()
+}
+
 type fsmState int
 
 const (
@@ -124,6 +388,17 @@ const (
 	fsmStateIndexEnded
 	fsmStateFieldSeparated
 	fsmStateField
+	fsmStateFieldEscaped
+	fsmStateQuotedDouble
+	fsmStateQuotedDoubleEscaped
+	fsmStateQuotedSingle
+	fsmStateQuotedSingleEscaped
+	fsmStateQuotedEnded
+	fsmStateWildcard
+	fsmStateRecursiveDescent
+	fsmStateSlice
+	fsmStateFilter
+	fsmStateUnion
 )
 
 func (s fsmState) Input(received rune) (fsmState, error) {
@@ -140,6 +415,24 @@ func (s fsmState) Input(received rune) (fsmState, error) {
 		return s.stateFieldSeparated(received)
 	case fsmStateField:
 		return s.stateField(received)
+	case fsmStateFieldEscaped:
+		return s.stateEscaped(received, fsmStateField)
+	case fsmStateQuotedDouble:
+		return s.stateQuoted(received, doubleQuote, fsmStateQuotedDoubleEscaped)
+	case fsmStateQuotedDoubleEscaped:
+		return s.stateEscaped(received, fsmStateQuotedDouble)
+	case fsmStateQuotedSingle:
+		return s.stateQuoted(received, singleQuote, fsmStateQuotedSingleEscaped)
+	case fsmStateQuotedSingleEscaped:
+		return s.stateEscaped(received, fsmStateQuotedSingle)
+	case fsmStateQuotedEnded:
+		return s.stateQuotedEnded(received)
+	case fsmStateWildcard:
+		return s.stateWildcard(received)
+	case fsmStateRecursiveDescent:
+		return s.stateRecursiveDescent(received)
+	case fsmStateSlice:
+		return s.stateSlice(received)
 	}
 
 	return s, ErrInvalidSelectorFormat
@@ -153,6 +446,9 @@ func (s fsmState) stateReset(received rune) (fsmState, error) {
 	case received == indexSeparatorStart:
 		return fsmStateIndexStarted, nil
 
+	case received == escapeCharacter:
+		return fsmStateFieldEscaped, nil
+
 	case unicode.IsControl(received):
 		return fsmStateReset, ErrInvalidSelectorFormatForName
 
@@ -167,6 +463,18 @@ func (s fsmState) stateIndexStarted(received rune) (fsmState, error) {
 		return fsmStateIndex, nil
 	case received == '-':
 		return fsmStateIndex, nil
+	case received == doubleQuote:
+		return fsmStateQuotedDouble, nil
+	case received == singleQuote:
+		return fsmStateQuotedSingle, nil
+	case received == wildcard:
+		return fsmStateWildcard, nil
+	case received == sliceSeparator:
+		return fsmStateSlice, nil
+	case received == filterStart:
+		return fsmStateFilter, nil
+	case unicode.IsSpace(received):
+		return fsmStateUnion, nil
 
 	default:
 		return fsmStateIndexStarted, ErrInvalidSelectorFormatForIndex
@@ -181,11 +489,33 @@ func (s fsmState) stateIndex(received rune) (fsmState, error) {
 	case received == indexSeparatorEnd:
 		return fsmStateIndexEnded, nil
 
+	case received == sliceSeparator:
+		return fsmStateSlice, nil
+
+	case received == unionSeparator || unicode.IsSpace(received):
+		return fsmStateUnion, nil
+
 	default:
 		return fsmStateIndex, ErrInvalidSelectorFormatForIndex
 	}
 }
 
+func (s fsmState) stateSlice(received rune) (fsmState, error) {
+	switch {
+	case unicode.IsNumber(received), received == '-', received == sliceSeparator:
+		return fsmStateSlice, nil
+
+	case received == indexSeparatorEnd:
+		return fsmStateIndexEnded, nil
+
+	case received == unionSeparator || unicode.IsSpace(received):
+		return fsmStateUnion, nil
+
+	default:
+		return fsmStateSlice, ErrInvalidSelectorFormatForIndex
+	}
+}
+
 func (s fsmState) stateIndexEnded(received rune) (fsmState, error) {
 	switch received {
 	case fieldSeparator:
@@ -207,6 +537,9 @@ func (s fsmState) stateField(received rune) (fsmState, error) {
 	case received == indexSeparatorStart:
 		return fsmStateIndexStarted, nil
 
+	case received == escapeCharacter:
+		return fsmStateFieldEscaped, nil
+
 	case unicode.IsControl(received):
 		return fsmStateField, ErrInvalidSelectorFormatForName
 
@@ -218,7 +551,10 @@ func (s fsmState) stateField(received rune) (fsmState, error) {
 func (s fsmState) stateFieldSeparated(received rune) (fsmState, error) {
 	switch {
 	case received == fieldSeparator:
-		return fsmStateFieldSeparated, ErrInvalidSelectorFormatForName
+		return fsmStateRecursiveDescent, nil
+
+	case received == escapeCharacter:
+		return fsmStateFieldEscaped, nil
 
 	case unicode.IsControl(received):
 		return fsmStateField, ErrInvalidSelectorFormatForName
@@ -228,6 +564,72 @@ func (s fsmState) stateFieldSeparated(received rune) (fsmState, error) {
 	}
 }
 
+func (s fsmState) stateRecursiveDescent(received rune) (fsmState, error) {
+	switch {
+	case received == fieldSeparator:
+		return fsmStateRecursiveDescent, ErrInvalidSelectorFormatForName
+
+	case received == indexSeparatorStart:
+		return fsmStateIndexStarted, nil
+
+	case received == escapeCharacter:
+		return fsmStateFieldEscaped, nil
+
+	case unicode.IsControl(received):
+		return fsmStateRecursiveDescent, ErrInvalidSelectorFormatForName
+
+	default:
+		return fsmStateField, nil
+	}
+}
+
+// stateEscaped accepts any (non control) character right after the escape character and returns to the `next` state.
+func (s fsmState) stateEscaped(received rune, next fsmState) (fsmState, error) {
+	if unicode.IsControl(received) {
+		return s, ErrInvalidSelectorFormatForName
+	}
+
+	return next, nil
+}
+
+func (s fsmState) stateQuoted(received, quote rune, escaped fsmState) (fsmState, error) {
+	switch {
+	case received == quote:
+		return fsmStateQuotedEnded, nil
+
+	case received == escapeCharacter:
+		return escaped, nil
+
+	case unicode.IsControl(received):
+		return s, ErrInvalidSelectorFormatForName
+
+	default:
+		return s, nil
+	}
+}
+
+func (s fsmState) stateWildcard(received rune) (fsmState, error) {
+	switch {
+	case received == indexSeparatorEnd:
+		return fsmStateIndexEnded, nil
+	case received == unionSeparator || unicode.IsSpace(received):
+		return fsmStateUnion, nil
+	}
+
+	return s, ErrInvalidSelectorFormatForIndex
+}
+
+func (s fsmState) stateQuotedEnded(received rune) (fsmState, error) {
+	switch {
+	case received == indexSeparatorEnd:
+		return fsmStateIndexEnded, nil
+	case received == unionSeparator || unicode.IsSpace(received):
+		return fsmStateUnion, nil
+	}
+
+	return s, ErrInvalidSelectorFormat
+}
+
 func (s fsmState) oneOf(states ...fsmState) bool {
 	return slices.Contains(states, s)
 }
@@ -244,8 +646,14 @@ func (d dotNotationParser) Parse(selector string) ([]Key, error) {
 	var (
 		lastState  fsmState
 		tokenStart int
+		skipUntil  int
 	)
 	for i, r := range selector {
+		// the filter expression has already been consumed.
+		if i < skipUntil {
+			continue
+		}
+
 		newState, err := lastState.Input(r)
 		if err != nil {
 			return nil, err
@@ -255,6 +663,52 @@ func (d dotNotationParser) Parse(selector string) ([]Key, error) {
 			continue
 		}
 
+		// <index started || index || slice || wildcard || quoted ended> -> <union>
+		//   => the whole bracket content, from the token start up to the matching `]`, is a new union token.
+		if newState == fsmStateUnion {
+			if lastState == fsmStateIndexStarted {
+				tokenStart = i
+			}
+			end, err := scanBracketContent(selector, tokenStart)
+			if err != nil {
+				return nil, err
+			}
+			k, err := d.parseUnionToken(selector[tokenStart:end])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+			skipUntil = end + 1
+			lastState = fsmStateIndexEnded
+			continue
+		}
+
+		// <index started> -> <filter>
+		//   => the whole filter expression, up to the matching `]`, is a new filter token.
+		if newState == fsmStateFilter {
+			end, err := scanBracketContent(selector, i+1)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidFilterExpression, err)
+			}
+			k, err := d.parseFilterToken(selector[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+			skipUntil = end + 1
+			lastState = fsmStateIndexEnded
+			continue
+		}
+
+		// <recursive descent> -> <...>
+		//   => new recursive descent token and a new token starts
+		if lastState == fsmStateRecursiveDescent {
+			keys = append(keys, RecursiveDescent())
+			tokenStart = i
+			lastState = newState
+			continue
+		}
+
 		// <reset> -> <...> or <field separated> -> <...> or <index started> -> <...>
 		//   => new token starts
 		if lastState.oneOf(fsmStateReset, fsmStateFieldSeparated, fsmStateIndexStarted) {
@@ -275,6 +729,38 @@ func (d dotNotationParser) Parse(selector string) ([]Key, error) {
 			continue
 		}
 
+		// <slice> -> <index ended>
+		//   => new slice token
+		if lastState == fsmStateSlice && newState == fsmStateIndexEnded {
+			k, err := d.parseSliceToken(selector[tokenStart:i])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+			lastState = newState
+			continue
+		}
+
+		// <wildcard> -> <index ended>
+		//   => new wildcard token
+		if lastState == fsmStateWildcard && newState == fsmStateIndexEnded {
+			keys = append(keys, Wildcard())
+			lastState = newState
+			continue
+		}
+
+		// <quoted ended> -> <index ended>
+		//   => new quoted field token
+		if lastState == fsmStateQuotedEnded && newState == fsmStateIndexEnded {
+			k, err := d.parseQuotedFieldToken(selector[tokenStart:i])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+			lastState = newState
+			continue
+		}
+
 		// <field> -> <field separated || index started>
 		//   => new field token
 		if lastState == fsmStateField && newState.oneOf(fsmStateFieldSeparated, fsmStateIndexStarted) {
@@ -294,15 +780,20 @@ func (d dotNotationParser) Parse(selector string) ([]Key, error) {
 	}
 
 	// ends with incomplete index, invalid.
-	if lastState.oneOf(fsmStateIndexStarted, fsmStateIndex) {
+	if lastState.oneOf(fsmStateIndexStarted, fsmStateIndex, fsmStateWildcard, fsmStateSlice) {
 		return nil, ErrInvalidSelectorFormatForIndex
 	}
 
-	// ends with incomplete field, invalid.
-	if lastState == fsmStateFieldSeparated {
+	// ends with incomplete field or incomplete quoted field, invalid.
+	if lastState.oneOf(fsmStateFieldSeparated, fsmStateRecursiveDescent, fsmStateFieldEscaped, fsmStateQuotedDouble, fsmStateQuotedDoubleEscaped, fsmStateQuotedSingle, fsmStateQuotedSingleEscaped) {
 		return nil, ErrInvalidSelectorFormatForName
 	}
 
+	// quoted field without closing bracket, invalid.
+	if lastState == fsmStateQuotedEnded {
+		return nil, ErrInvalidSelectorFormat
+	}
+
 	// ends with field, valid but must parse.
 	if lastState == fsmStateField {
 		k, err := d.parseFieldToken(selector[tokenStart:])
@@ -339,16 +830,205 @@ func (d dotNotationParser) parseIndexToken(token string) (Key, error) {
 	return k, nil
 }
 
+// parseSliceToken parses a `start:end:step` token, where every part is optional.
+func (d dotNotationParser) parseSliceToken(token string) (Key, error) {
+	const maxParts = 3
+	parts := strings.SplitN(token, string(sliceSeparator), maxParts+1)
+	if len(parts) > maxParts {
+		return Key{}, ErrInvalidSelectorFormatForIndex
+	}
+
+	bounds := make([]*int, maxParts)
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Key{}, errors.Join(ErrInvalidSelectorFormatForIndex, err)
+		}
+		bounds[i] = &n
+	}
+
+	step := 0
+	if bounds[2] != nil {
+		step = *bounds[2]
+	}
+
+	return Slice(bounds[0], bounds[1], step), nil
+}
+
+// parseUnionToken parses the comma separated members of a union (e.g. `'name', "email"` or `0,2,5`).
+// A single member (e.g. `[ 0 ]`) results to the member key itself.
+func (d dotNotationParser) parseUnionToken(token string) (Key, error) {
+	members, err := splitUnionMembers(token)
+	if err != nil {
+		return Key{}, err
+	}
+
+	keys := make([]Key, 0, len(members))
+	for _, m := range members {
+		k, err := d.parseUnionMember(m)
+		if err != nil {
+			return Key{}, err
+		}
+		keys = append(keys, k)
+	}
+
+	if len(keys) == 1 {
+		return keys[0], nil
+	}
+
+	return Union(keys...), nil
+}
+
+func (d dotNotationParser) parseUnionMember(member string) (Key, error) {
+	switch {
+	case member == "":
+		return Key{}, ErrInvalidSelectorFormat
+	case member[0] == byte(doubleQuote) || member[0] == byte(singleQuote):
+		end, err := scanQuoted(member, 0)
+		if err != nil || end != len(member) {
+			return Key{}, ErrInvalidSelectorFormatForName
+		}
+		return d.parseQuotedFieldToken(member)
+	case member == string(wildcard):
+		return Wildcard(), nil
+	case member[0] == byte(filterStart):
+		return d.parseFilterToken(member[1:])
+	case strings.ContainsRune(member, sliceSeparator):
+		return d.parseSliceToken(member)
+	default:
+		k, err := d.parseIndexToken(member)
+		if err != nil {
+			return Key{}, errors.Join(ErrInvalidSelectorFormatForIndex, err)
+		}
+		return k, nil
+	}
+}
+
+// splitUnionMembers splits the union token at the commas that are not quoted and trims the blank characters around each member.
+func splitUnionMembers(token string) ([]string, error) {
+	var members []string
+	start := 0
+	for pos := 0; pos < len(token); {
+		switch token[pos] {
+		case byte(doubleQuote), byte(singleQuote):
+			end, err := scanQuoted(token, pos)
+			if err != nil {
+				return nil, err
+			}
+			pos = end
+			continue
+		case byte(unionSeparator):
+			members = append(members, strings.TrimSpace(token[start:pos]))
+			start = pos + 1
+		}
+		pos++
+	}
+
+	return append(members, strings.TrimSpace(token[start:])), nil
+}
+
+// scanBracketContent returns the position of the `]` that closes the bracket content that starts at `pos`.
+// Nested brackets and quoted strings inside the content are skipped.
+func scanBracketContent(selector string, pos int) (int, error) {
+	depth := 0
+	for pos < len(selector) {
+		switch selector[pos] {
+		case byte(doubleQuote), byte(singleQuote):
+			end, err := scanQuoted(selector, pos)
+			if err != nil {
+				return pos, err
+			}
+			pos = end
+			continue
+		case byte(indexSeparatorStart):
+			depth++
+		case byte(indexSeparatorEnd):
+			if depth == 0 {
+				return pos, nil
+			}
+			depth--
+		}
+		pos++
+	}
+
+	return pos, ErrInvalidSelectorFormat
+}
+
+// scanQuoted returns the position right after the quoted string that starts at `pos` (the opening quote).
+func scanQuoted(src string, pos int) (int, error) {
+	quote := src[pos]
+	for i := pos + 1; i < len(src); i++ {
+		switch src[i] {
+		case byte(escapeCharacter):
+			i++
+		case quote:
+			return i + 1, nil
+		}
+	}
+
+	return pos, ErrInvalidSelectorFormatForName
+}
+
+// parseFilterToken compiles the filter expression (e.g. `(@.type == "primary")`), which is kept in the key.
+func (d dotNotationParser) parseFilterToken(token string) (Key, error) {
+	expr, err := compileFilter(token)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{Name: token, Type: KeyTypeFilter, filter: &compiledFilter{expr: expr}}, nil
+}
+
 func (d dotNotationParser) parseFieldToken(token string) (Key, error) {
 	k := Key{Type: KeyTypeField}
 	if len(token) == 0 {
 		return k, ErrInvalidSelectorFormatForName
 	}
 
-	k.Name = token
+	// a non escaped `*` is the wildcard (e.g. `users.*.email`).
+	if token == string(wildcard) {
+		return Wildcard(), nil
+	}
+
+	k.Name = unescape(token)
 	return k, nil
 }
 
+// parseQuotedFieldToken parses a token that includes the surrounding quotes (e.g. `"content.type"`).
+// Quoted field tokens are allowed to be empty.
+func (d dotNotationParser) parseQuotedFieldToken(token string) (Key, error) {
+	const quotesLen = 2
+	if len(token) < quotesLen {
+		return Key{}, ErrInvalidSelectorFormatForName
+	}
+
+	return Field(unescape(token[1 : len(token)-1])), nil
+}
+
+// unescape removes the escape character, keeping the character that follows it as is.
+func unescape(token string) string {
+	if !strings.ContainsRune(token, escapeCharacter) {
+		return token
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(token))
+	escaped := false
+	for _, r := range token {
+		if r == escapeCharacter && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
 var (
 	ErrInvalidSelectorFormatForName  = errors.New("invalid format for name key")
 	ErrInvalidSelectorFormatForIndex = errors.New("invalid format for index key")
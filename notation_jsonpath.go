@@ -0,0 +1,375 @@
+package pick
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	jsonPathRoot        byte = '$'
+	jsonPathSingleQuote byte = '\''
+	jsonPathDoubleQuote byte = '"'
+)
+
+// JSONPathNotation implements the Notation using the JSONPath syntax (RFC 9535).
+// Example: `$.store.book[0].title` or `$['odd.key'][-1]`.
+// The leading root identifier `$` is optional when parsing and always emitted when formatting.
+type JSONPathNotation struct {
+	jsonPathFormatter
+	jsonPathParser
+}
+
+type jsonPathFormatter struct{}
+
+func (j jsonPathFormatter) formatKey(sb *strings.Builder, k Key, afterRecursiveDescent bool) {
+	switch k.Type {
+	case KeyTypeIndex:
+		sb.WriteByte(byte(indexSeparatorStart))
+		sb.WriteString(strconv.Itoa(k.Index))
+		sb.WriteByte(byte(indexSeparatorEnd))
+	case KeyTypeWildcard:
+		sb.WriteString("[*]")
+	case KeyTypeRecursiveDescent:
+		sb.WriteString("..")
+	case KeyTypeSlice, KeyTypeFilter:
+		sb.WriteString(dotNotationFormatter{}.formatKey(k))
+	case KeyTypeUnion:
+		sb.WriteByte(byte(indexSeparatorStart))
+		for i, m := range k.UnionKeys() {
+			if i > 0 {
+				sb.WriteRune(unionSeparator)
+			}
+			j.formatUnionMember(sb, m)
+		}
+		sb.WriteByte(byte(indexSeparatorEnd))
+	case KeyTypeField:
+		if isJSONPathShorthandName(k.Name) {
+			if !afterRecursiveDescent {
+				sb.WriteByte(byte(fieldSeparator))
+			}
+			sb.WriteString(k.Name)
+			return
+		}
+		sb.WriteByte(byte(indexSeparatorStart))
+		writeJSONPathQuoted(sb, k.Name)
+		sb.WriteByte(byte(indexSeparatorEnd))
+	}
+}
+
+// formatUnionMember formats a key as a member of a union, which is the format of the key inside the brackets.
+func (j jsonPathFormatter) formatUnionMember(sb *strings.Builder, k Key) {
+	switch k.Type {
+	case KeyTypeField:
+		writeJSONPathQuoted(sb, k.Name)
+	case KeyTypeIndex:
+		sb.WriteString(strconv.Itoa(k.Index))
+	default:
+		sb.WriteString(dotNotationFormatter{}.formatUnionMember(k))
+	}
+}
+
+func (j jsonPathFormatter) Format(path ...Key) string {
+	sb := strings.Builder{}
+	sb.WriteByte(jsonPathRoot)
+	for i, k := range path {
+		j.formatKey(&sb, k, i > 0 && path[i-1].IsRecursiveDescent())
+	}
+
+	return sb.String()
+}
+
+type jsonPathParser struct{}
+
+func (j jsonPathParser) Parse(selector string) ([]Key, error) {
+	if len(selector) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]Key, 0, dotNotationParser{}.estimatePathSize(selector))
+
+	pos := 0
+	switch selector[0] {
+	case jsonPathRoot:
+		pos++
+	case byte(indexSeparatorStart), byte(fieldSeparator):
+	default:
+		// relative selectors (without the root identifier) may start directly with a member name.
+		k, next, err := j.parseMemberName(selector, pos)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+		pos = next
+	}
+
+	for pos < len(selector) {
+		var (
+			k    Key
+			next int
+			err  error
+		)
+
+		switch {
+		case strings.HasPrefix(selector[pos:], ".."):
+			// recursive descent, followed either by a member name or a bracketed selector.
+			keys = append(keys, RecursiveDescent())
+			if pos+2 < len(selector) && selector[pos+2] == byte(indexSeparatorStart) {
+				pos += 2
+				continue
+			}
+			k, next, err = j.parseMemberName(selector, pos+2)
+		case selector[pos] == byte(fieldSeparator):
+			k, next, err = j.parseMemberName(selector, pos+1)
+		case selector[pos] == byte(indexSeparatorStart):
+			k, next, err = j.parseBracket(selector, pos+1)
+		default:
+			err = ErrInvalidSelectorFormat
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, k)
+		pos = next
+	}
+
+	return keys, nil
+}
+
+// parseMemberName parses a dot (shorthand) member name that starts at `pos` and returns the key and the position right after it.
+func (j jsonPathParser) parseMemberName(selector string, pos int) (Key, int, error) {
+	end := pos
+	for end < len(selector) {
+		r, size := utf8.DecodeRuneInString(selector[end:])
+		if r == fieldSeparator || r == indexSeparatorStart {
+			break
+		}
+		if unicode.IsControl(r) || r == indexSeparatorEnd {
+			return Key{}, end, ErrInvalidSelectorFormatForName
+		}
+		end += size
+	}
+
+	if end == pos {
+		return Key{}, end, ErrInvalidSelectorFormatForName
+	}
+
+	if selector[pos:end] == string(wildcard) {
+		return Wildcard(), end, nil
+	}
+
+	return Field(selector[pos:end]), end, nil
+}
+
+// parseBracket parses the content of a bracketed selector (`['name']`, `["name"]`, `[*]`, `[1:4]`, `[?(@.a > 1)]`, `[12]` or
+// a comma separated union of them, e.g. `['name','email']`) that starts at `pos` (right after `[`)
+// and returns the key and the position right after the closing `]`.
+func (j jsonPathParser) parseBracket(selector string, pos int) (Key, int, error) {
+	var members []Key
+	for {
+		k, next, err := j.parseBracketMember(selector, pos)
+		if err != nil {
+			return Key{}, next, err
+		}
+		members = append(members, k)
+
+		pos = skipJSONPathBlank(selector, next)
+		if pos >= len(selector) {
+			return Key{}, pos, ErrInvalidSelectorFormat
+		}
+
+		switch selector[pos] {
+		case byte(indexSeparatorEnd):
+			if len(members) == 1 {
+				return members[0], pos + 1, nil
+			}
+			return Union(members...), pos + 1, nil
+		case byte(unionSeparator):
+			pos++
+		default:
+			return Key{}, pos, ErrInvalidSelectorFormat
+		}
+	}
+}
+
+// parseBracketMember parses a single member of a bracketed selector that starts at `pos` and returns the key and the position right after it.
+func (j jsonPathParser) parseBracketMember(selector string, pos int) (Key, int, error) {
+	pos = skipJSONPathBlank(selector, pos)
+	if pos >= len(selector) {
+		return Key{}, pos, ErrInvalidSelectorFormat
+	}
+
+	switch selector[pos] {
+	case jsonPathSingleQuote, jsonPathDoubleQuote:
+		name, next, err := unquoteJSONPathString(selector, pos)
+		if err != nil {
+			return Key{}, next, err
+		}
+		return Field(name), next, nil
+
+	case byte(wildcard):
+		return Wildcard(), pos + 1, nil
+
+	case byte(filterStart):
+		end, err := scanBracketContent(selector, pos+1)
+		if err != nil {
+			return Key{}, pos, fmt.Errorf("%w: %w", ErrInvalidFilterExpression, err)
+		}
+		k, err := dotNotationParser{}.parseFilterToken(selector[pos+1 : end])
+		return k, end, err
+
+	default:
+		start := pos
+		for pos < len(selector) && !strings.ContainsRune("], \t\n\r", rune(selector[pos])) {
+			pos++
+		}
+		token := selector[start:pos]
+
+		var (
+			k   Key
+			err error
+		)
+		if strings.ContainsRune(token, sliceSeparator) {
+			k, err = dotNotationParser{}.parseSliceToken(token)
+		} else {
+			k, err = dotNotationParser{}.parseIndexToken(token)
+		}
+		if err != nil {
+			return Key{}, pos, ErrInvalidSelectorFormatForIndex
+		}
+		return k, pos, nil
+	}
+}
+
+func skipJSONPathBlank(selector string, pos int) int {
+	for pos < len(selector) {
+		switch selector[pos] {
+		case ' ', '\t', '\n', '\r':
+			pos++
+		default:
+			return pos
+		}
+	}
+
+	return pos
+}
+
+// unquoteJSONPathString parses a single or double quoted string literal that starts at `pos` (the opening quote)
+// and returns the unescaped value and the position right after the closing quote.
+func unquoteJSONPathString(selector string, pos int) (string, int, error) {
+	quote := selector[pos]
+	pos++
+
+	sb := strings.Builder{}
+	for pos < len(selector) {
+		c := selector[pos]
+		switch {
+		case c == quote:
+			return sb.String(), pos + 1, nil
+
+		case c == '\\':
+			if pos+1 >= len(selector) {
+				return "", pos, ErrInvalidSelectorFormatForName
+			}
+			r, size, err := unescapeJSONPathChar(selector[pos+1:])
+			if err != nil {
+				return "", pos, err
+			}
+			sb.WriteRune(r)
+			pos += 1 + size
+
+		default:
+			r, size := utf8.DecodeRuneInString(selector[pos:])
+			if unicode.IsControl(r) {
+				return "", pos, ErrInvalidSelectorFormatForName
+			}
+			sb.WriteRune(r)
+			pos += size
+		}
+	}
+
+	// string literal never closed.
+	return "", pos, ErrInvalidSelectorFormatForName
+}
+
+// unescapeJSONPathChar decodes the escape sequence that follows a backslash and returns the rune and the number of bytes consumed.
+func unescapeJSONPathChar(s string) (rune, int, error) {
+	switch s[0] {
+	case 'b':
+		return '\b', 1, nil
+	case 'f':
+		return '\f', 1, nil
+	case 'n':
+		return '\n', 1, nil
+	case 'r':
+		return '\r', 1, nil
+	case 't':
+		return '\t', 1, nil
+	case '/', '\\', '\'', '"':
+		return rune(s[0]), 1, nil
+	case 'u':
+		const hexLen = 4
+		if len(s) < 1+hexLen {
+			return 0, 0, ErrInvalidSelectorFormatForName
+		}
+		code, err := strconv.ParseUint(s[1:1+hexLen], 16, 32)
+		if err != nil {
+			return 0, 0, ErrInvalidSelectorFormatForName
+		}
+		r := rune(code)
+		if !utf16.IsSurrogate(r) {
+			return r, 1 + hexLen, nil
+		}
+
+		// like encoding/json, a surrogate pair is combined into a single rune, while a lone surrogate becomes U+FFFD.
+		if rest := s[1+hexLen:]; len(rest) >= 2+hexLen && rest[0] == '\\' && rest[1] == 'u' {
+			if low, err := strconv.ParseUint(rest[2:2+hexLen], 16, 32); err == nil {
+				if combined := utf16.DecodeRune(r, rune(low)); combined != unicode.ReplacementChar {
+					return combined, 1 + hexLen + 2 + hexLen, nil
+				}
+			}
+		}
+		return unicode.ReplacementChar, 1 + hexLen, nil
+	default:
+		return 0, 0, ErrInvalidSelectorFormatForName
+	}
+}
+
+// isJSONPathShorthandName reports whether the name can be formatted using the dot shorthand (`.name`) instead of the bracket form.
+func isJSONPathShorthandName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_', r >= utf8.RuneSelf && !unicode.IsControl(r) && !unicode.IsSpace(r):
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeJSONPathQuoted(sb *strings.Builder, name string) {
+	sb.WriteByte(jsonPathSingleQuote)
+	for _, r := range name {
+		switch {
+		case r == '\'' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(sb, `\u%04x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte(jsonPathSingleQuote)
+}
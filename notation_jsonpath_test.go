@@ -0,0 +1,225 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+)
+
+func TestJSONPathNotation(t *testing.T) {
+	tests := []struct {
+		errorAsserter     tst.ErrorAssertionFunc
+		input             string
+		expectedPath      []Key
+		expectedFormatted string
+	}{
+		{
+			input:             "",
+			expectedPath:      nil,
+			expectedFormatted: "$",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "$",
+			expectedPath:  []Key{},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "$.store.book[0].title",
+			expectedPath:  []Key{Field("store"), Field("book"), Index(0), Field("title")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "$['odd.key']",
+			expectedPath:  []Key{Field("odd.key")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `$["odd.key"][-1]`,
+			expectedPath:      []Key{Field("odd.key"), Index(-1)},
+			expectedFormatted: "$['odd.key'][-1]",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             "$[ 'a' ][ 2 ]",
+			expectedPath:      []Key{Field("a"), Index(2)},
+			expectedFormatted: "$.a[2]",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         `$['it\'s']['back\\slash']`,
+			expectedPath:  []Key{Field("it's"), Field(`back\slash`)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `$["tab\there"]['é']`,
+			expectedPath:      []Key{Field("tab\there"), Field("é")},
+			expectedFormatted: `$['tab\u0009here'].é`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             `$['\uD83D\uDE00']['\uD83Dx']`,
+			expectedPath:      []Key{Field("\U0001F600"), Field("\uFFFDx")},
+			expectedFormatted: "$.\U0001F600.\uFFFDx",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             "store.book[1]",
+			expectedPath:      []Key{Field("store"), Field("book"), Index(1)},
+			expectedFormatted: "$.store.book[1]",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             "[1].a",
+			expectedPath:      []Key{Index(1), Field("a")},
+			expectedFormatted: "$[1].a",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             "$.near_earth_objects['2023-01-01'][5]",
+			expectedPath:      []Key{Field("near_earth_objects"), Field("2023-01-01"), Index(5)},
+			expectedFormatted: "$.near_earth_objects['2023-01-01'][5]",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "$.ελληνικά[3]",
+			expectedPath:  []Key{Field("ελληνικά"), Index(3)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             "$.store.*[*].title",
+			expectedPath:      []Key{Field("store"), Wildcard(), Wildcard(), Field("title")},
+			expectedFormatted: "$.store[*][*].title",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "$..book[0]..['odd.key']..[*]",
+			expectedPath:  []Key{RecursiveDescent(), Field("book"), Index(0), RecursiveDescent(), Field("odd.key"), RecursiveDescent(), Wildcard()},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             "..id",
+			expectedPath:      []Key{RecursiveDescent(), Field("id")},
+			expectedFormatted: "$..id",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             "$.items[1:4][ -3: ][::2]",
+			expectedPath:      []Key{Field("items"), Slice(ptr(1), ptr(4), 0), Slice(ptr(-3), nil, 0), Slice(nil, nil, 2)},
+			expectedFormatted: "$.items[1:4][-3:][::2]",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "$.items[?(@.price < 10 || @.sale)].name",
+			expectedPath:  []Key{Field("items"), Filter("(@.price < 10 || @.sale)"), Field("name")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `$.user[ 'name', "email" ].items[0,-1,1:2]`,
+			expectedPath:      []Key{Field("user"), Union(Field("name"), Field("email")), Field("items"), Union(Index(0), Index(-1), Slice(ptr(1), ptr(2), 0))},
+			expectedFormatted: `$.user['name','email'].items[0,-1,1:2]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "$.a[0,",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         "$['*']",
+			expectedPath:  []Key{Field("*")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "$.a.",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         "$.a[",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         "$.a[r]",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         "$['a'",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         "$['a",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         `$['\x']`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         "$a",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+	}
+
+	n := JSONPathNotation{}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := n.Parse(tc.input)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expectedPath)
+
+			if err != nil {
+				return
+			}
+
+			gotFormatted := n.Format(got...)
+			expectedFormatted := tc.input
+			if tc.expectedFormatted != "" {
+				expectedFormatted = tc.expectedFormatted
+			}
+			testingx.AssertEqual(t, gotFormatted, expectedFormatted)
+
+			// formatted output must parse back to the same path.
+			reparsed, err := n.Parse(gotFormatted)
+			tst.NoError()(t, err)
+			if len(got) > 0 {
+				testingx.AssertEqual(t, reparsed, got)
+			}
+		})
+	}
+}
+
+func TestJSONPathNotationPicker(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"book": []any{
+				map[string]any{"title": "first"},
+				map[string]any{"title": "second"},
+			},
+		},
+		"odd.key": 42,
+	}
+
+	converter := NewDefaultConverter()
+	p := NewPicker(data, NewDefaultTraverser(converter), converter, JSONPathNotation{})
+
+	title, err := p.String("$.store.book[0].title")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, title, "first")
+
+	title, err = p.String("$.store.book[-1]['title']")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, title, "second")
+
+	n, err := p.Int("$['odd.key']")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, n, 42)
+}
@@ -0,0 +1,146 @@
+package pick
+
+import (
+	"strconv"
+	"strings"
+)
+
+const jsonPointerSeparator = '/'
+
+// JSONPointerNotation implements the Notation using the JSON Pointer syntax (RFC 6901).
+// Example: `/items/3/name` or `/a~1b/m~0n` (where `~1` is the escaped form of `/` and `~0` the escaped form of `~`).
+// Reference tokens that are array indices (digits without leading zeros) are parsed as index keys, every other token as a field key.
+type JSONPointerNotation struct {
+	jsonPointerFormatter
+	jsonPointerParser
+}
+
+type jsonPointerFormatter struct{}
+
+func (j jsonPointerFormatter) Format(path ...Key) string {
+	sb := strings.Builder{}
+	for _, k := range path {
+		sb.WriteByte(jsonPointerSeparator)
+		switch k.Type {
+		case KeyTypeIndex:
+			sb.WriteString(strconv.Itoa(k.Index))
+		case KeyTypeField:
+			writeJSONPointerEscaped(&sb, k.Name)
+		// the following are not part of RFC 6901, they are rendered only for display purposes (e.g. errors).
+		case KeyTypeWildcard:
+			sb.WriteRune(wildcard)
+		case KeyTypeRecursiveDescent:
+			sb.WriteString("..")
+		case KeyTypeSlice:
+			if k.Slice != nil {
+				sb.WriteString(k.Slice.String())
+			}
+		case KeyTypeFilter:
+			sb.WriteRune(filterStart)
+			sb.WriteString(k.Name)
+		case KeyTypeUnion:
+			sb.WriteString(dotNotationFormatter{}.formatKey(k))
+		}
+	}
+
+	return sb.String()
+}
+
+type jsonPointerParser struct{}
+
+func (j jsonPointerParser) Parse(selector string) ([]Key, error) {
+	if len(selector) == 0 {
+		return nil, nil
+	}
+
+	if selector[0] != jsonPointerSeparator {
+		return nil, ErrInvalidSelectorFormat
+	}
+
+	keys := make([]Key, 0, strings.Count(selector, string(jsonPointerSeparator)))
+
+	rest := selector[1:]
+	for {
+		token, remaining, more := strings.Cut(rest, string(jsonPointerSeparator))
+
+		k, err := j.parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+
+		if !more {
+			break
+		}
+		rest = remaining
+	}
+
+	return keys, nil
+}
+
+func (j jsonPointerParser) parseToken(token string) (Key, error) {
+	if isJSONPointerArrayIndex(token) {
+		i, err := strconv.Atoi(token)
+		if err == nil {
+			return Index(i), nil
+		}
+	}
+
+	if !strings.ContainsRune(token, '~') {
+		return Field(token), nil
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(token))
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		if c != '~' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		if i+1 >= len(token) {
+			return Key{}, ErrInvalidSelectorFormatForName
+		}
+
+		i++
+		switch token[i] {
+		case '0':
+			sb.WriteByte('~')
+		case '1':
+			sb.WriteByte(jsonPointerSeparator)
+		default:
+			return Key{}, ErrInvalidSelectorFormatForName
+		}
+	}
+
+	return Field(sb.String()), nil
+}
+
+// isJSONPointerArrayIndex reports whether the token follows the array-index rule of RFC 6901 (`0` or digits without leading zero).
+func isJSONPointerArrayIndex(token string) bool {
+	if len(token) == 0 || (len(token) > 1 && token[0] == '0') {
+		return false
+	}
+
+	for i := range len(token) {
+		if token[i] < '0' || token[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeJSONPointerEscaped(sb *strings.Builder, name string) {
+	for i := range len(name) {
+		switch name[i] {
+		case '~':
+			sb.WriteString("~0")
+		case jsonPointerSeparator:
+			sb.WriteString("~1")
+		default:
+			sb.WriteByte(name[i])
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPointerNotation(t *testing.T) {
+	tests := []struct {
+		errorAsserter     tst.ErrorAssertionFunc
+		input             string
+		expectedPath      []Key
+		expectedFormatted string
+	}{
+		{
+			input:         "",
+			expectedPath:  nil,
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/",
+			expectedPath:  []Key{Field("")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/items/3/name",
+			expectedPath:  []Key{Field("items"), Index(3), Field("name")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/a~1b/m~0n",
+			expectedPath:  []Key{Field("a/b"), Field("m~n")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/~01",
+			expectedPath:  []Key{Field("~1")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/items/03/-",
+			expectedPath:  []Key{Field("items"), Field("03"), Field("-")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/0/0",
+			expectedPath:  []Key{Index(0), Index(0)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/a//b",
+			expectedPath:  []Key{Field("a"), Field(""), Field("b")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "/ελληνικά/1",
+			expectedPath:  []Key{Field("ελληνικά"), Index(1)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "items/3",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         "/a~2",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         "/a~",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+	}
+
+	n := JSONPointerNotation{}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := n.Parse(tc.input)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expectedPath)
+
+			if err != nil {
+				return
+			}
+
+			gotFormatted := n.Format(got...)
+			expectedFormatted := tc.input
+			if tc.expectedFormatted != "" {
+				expectedFormatted = tc.expectedFormatted
+			}
+			testingx.AssertEqual(t, gotFormatted, expectedFormatted)
+		})
+	}
+}
+
+func TestJSONPointerNotationPicker(t *testing.T) {
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"name": "zero"},
+			map[string]any{"name": "one", "a/b": "slash"},
+		},
+	}
+
+	converter := NewDefaultConverter()
+	p := NewPicker(data, NewDefaultTraverser(converter), converter, JSONPointerNotation{})
+
+	name, err := p.String("/items/1/name")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, name, "one")
+
+	slash, err := p.String("/items/1/a~1b")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, slash, "slash")
+
+	_, err = p.String("/items/1/missing/deep")
+	tst.All(
+		tst.ErrorIs(ErrFieldNotFound),
+		tst.ErrorOfType[*TraverseError](
+			func(t tst.TestingT, te *TraverseError) { //nolint:thelper
+				assert.Equal(t, "selector: /items/1/missing : error trying to traverse: field not found", te.Error())
+			},
+		),
+	)(t, err)
+
+	// leading zeros are not array indices (RFC 6901).
+	_, err = p.String("/items/01/name")
+	tst.ErrorIs(ErrKeyConvert)(t, err)
+	err = p.ApplyPatch([]PatchOperation{{Op: PatchOpRemove, Path: "/items/01"}})
+	tst.ErrorIs(ErrKeyConvert)(t, err)
+	l, err := p.Len("/items")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, l, 2)
+
+	sink := &ErrorsSink{}
+	_ = RelaxedPath[string](p.Relaxed(sink), Field("items"), Index(7))
+	tst.ErrorOfType[*PickerError](
+		func(t tst.TestingT, pe *PickerError) { //nolint:thelper
+			assert.Equal(t, "/items/7", pe.Selector())
+		},
+	)(t, sink.Outcome())
+}
@@ -92,6 +92,246 @@ func TestDotNotation(t *testing.T) {
 			expectedPath:  []Key(nil),
 			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
 		},
+		{
+			input:         `headers["content.type"]`,
+			expectedPath:  []Key{Field("headers"), Field("content.type")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `a['x.y'].b`,
+			expectedPath:      []Key{Field("a"), Field("x.y"), Field("b")},
+			expectedFormatted: `a["x.y"].b`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         `["example.com"][0]["a[b]"]`,
+			expectedPath:  []Key{Field("example.com"), Index(0), Field("a[b]")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         `dates["2023.01"]`,
+			expectedPath:  []Key{Field("dates"), Field("2023.01")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `a["say \"hi\""]['it\'s']["back\\slash"]`,
+			expectedPath:      []Key{Field("a"), Field(`say "hi"`), Field("it's"), Field(`back\slash`)},
+			expectedFormatted: `a["say \"hi\""]["it's"]["back\\slash"]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         `a[""]`,
+			expectedPath:  []Key{Field("a"), Field("")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `example\.com.a\[b\]`,
+			expectedPath:      []Key{Field("example.com"), Field("a[b]")},
+			expectedFormatted: `["example.com"]["a[b]"]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:             `\\x`,
+			expectedPath:      []Key{Field(`\x`)},
+			expectedFormatted: `["\\x"]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "items[*].id",
+			expectedPath:  []Key{Field("items"), Wildcard(), Field("id")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             "users.*.email",
+			expectedPath:      []Key{Field("users"), Wildcard(), Field("email")},
+			expectedFormatted: "users[*].email",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "[*][*]",
+			expectedPath:  []Key{Wildcard(), Wildcard()},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         `a["*"]`,
+			expectedPath:  []Key{Field("a"), Field("*")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `a.\*.b*`,
+			expectedPath:      []Key{Field("a"), Field("*"), Field("b*")},
+			expectedFormatted: `a["*"].b*`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "..id",
+			expectedPath:  []Key{RecursiveDescent(), Field("id")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "a..b[0]..[1]..[*]",
+			expectedPath:  []Key{Field("a"), RecursiveDescent(), Field("b"), Index(0), RecursiveDescent(), Index(1), RecursiveDescent(), Wildcard()},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             "a..*",
+			expectedPath:      []Key{Field("a"), RecursiveDescent(), Wildcard()},
+			expectedFormatted: "a..[*]",
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         `..["x.y"]`,
+			expectedPath:  []Key{RecursiveDescent(), Field("x.y")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "items[1:4]",
+			expectedPath:  []Key{Field("items"), Slice(ptr(1), ptr(4), 0)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "items[-3:]",
+			expectedPath:  []Key{Field("items"), Slice(ptr(-3), nil, 0)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "items[::2].id",
+			expectedPath:  []Key{Field("items"), Slice(nil, nil, 2), Field("id")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "[:][4:0:-1]",
+			expectedPath:  []Key{Slice(nil, nil, 0), Slice(ptr(4), ptr(0), -1)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         "a[1:2:3:4]",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         "a[1:-]",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         "a[1:x]",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         "a[1:",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         `items[?(@.type=="primary")].value`,
+			expectedPath:  []Key{Field("items"), Filter(`(@.type=="primary")`), Field("value")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         `a[?@.tags[0] == 'x]' && !@["b.c"]][0]`,
+			expectedPath:  []Key{Field("a"), Filter(`@.tags[0] == 'x]' && !@["b.c"]`), Index(0)},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:         `a[?(@.b == ]`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidFilterExpression),
+		},
+		{
+			input:         `a[?(@.b == 1)`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidFilterExpression),
+		},
+		{
+			input:             `user['name','email']`,
+			expectedPath:      []Key{Field("user"), Union(Field("name"), Field("email"))},
+			expectedFormatted: `user["name","email"]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         "items[0,2,5].id",
+			expectedPath:  []Key{Field("items"), Union(Index(0), Index(2), Index(5)), Field("id")},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `a[-1, "x,y" , 1:3,*]`,
+			expectedPath:      []Key{Field("a"), Union(Index(-1), Field("x,y"), Slice(ptr(1), ptr(3), 0), Wildcard())},
+			expectedFormatted: `a[-1,"x,y",1:3,*]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         `a[0,?@.b]`,
+			expectedPath:  []Key{Field("a"), Union(Index(0), Filter("@.b"))},
+			errorAsserter: tst.NoError(),
+		},
+		{
+			input:             `a[ 1 ]`,
+			expectedPath:      []Key{Field("a"), Index(1)},
+			expectedFormatted: `a[1]`,
+			errorAsserter:     tst.NoError(),
+		},
+		{
+			input:         `a[0,]`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         `a[0,x]`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         `a["x"y,1]`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         `a[0,1`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         "a...b",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         "a..",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         "a[*",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         "a[*1]",
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+		{
+			input:         `a["b"`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         `a["b`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
+		{
+			input:         `a["b"c]`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormat),
+		},
+		{
+			input:         `a\`,
+			expectedPath:  []Key(nil),
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForName),
+		},
 	}
 
 	dsf := DotNotation{}
@@ -114,10 +354,63 @@ func TestDotNotation(t *testing.T) {
 				expectedFormatted = tc.expectedFormatted
 			}
 			testingx.AssertEqual(t, expectedFormatted, gotFormatted)
+
+			// parse the formatted selector and check the round trip.
+			reparsed, err := dsf.Parse(gotFormatted)
+			tst.NoError()(t, err)
+			testingx.AssertEqual(t, reparsed, got)
 		})
 	}
 }
 
+func TestSliceBoundsIndices(t *testing.T) {
+	tests := map[string]struct {
+		bounds   SliceBounds
+		length   int
+		expected []int
+	}{
+		"all":                    {bounds: SliceBounds{}, length: 3, expected: []int{0, 1, 2}},
+		"start end":              {bounds: SliceBounds{Start: ptr(1), End: ptr(4)}, length: 6, expected: []int{1, 2, 3}},
+		"negative start":         {bounds: SliceBounds{Start: ptr(-3)}, length: 5, expected: []int{2, 3, 4}},
+		"negative end":           {bounds: SliceBounds{End: ptr(-1)}, length: 3, expected: []int{0, 1}},
+		"step":                   {bounds: SliceBounds{Step: 2}, length: 5, expected: []int{0, 2, 4}},
+		"out of range":           {bounds: SliceBounds{Start: ptr(-10), End: ptr(10)}, length: 2, expected: []int{0, 1}},
+		"start after end":        {bounds: SliceBounds{Start: ptr(3), End: ptr(1)}, length: 5, expected: nil},
+		"reverse":                {bounds: SliceBounds{Step: -1}, length: 3, expected: []int{2, 1, 0}},
+		"reverse with bounds":    {bounds: SliceBounds{Start: ptr(4), End: ptr(0), Step: -2}, length: 6, expected: []int{4, 2}},
+		"reverse out of range":   {bounds: SliceBounds{Start: ptr(10), End: ptr(-10), Step: -1}, length: 2, expected: []int{1, 0}},
+		"empty":                  {bounds: SliceBounds{}, length: 0, expected: nil},
+		"reverse negative bound": {bounds: SliceBounds{Start: ptr(-1), End: ptr(-3), Step: -1}, length: 4, expected: []int{3, 2}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			testingx.AssertEqual(t, tc.bounds.Indices(tc.length), tc.expected)
+		})
+	}
+}
+
+func TestDotNotationQuotedFieldsPicker(t *testing.T) {
+	p := Wrap(map[string]any{
+		"headers": map[string]any{
+			"content.type": "application/json",
+		},
+		"a[b]": []any{"zero", "one"},
+	})
+
+	got, err := p.String(`headers["content.type"]`)
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, got, "application/json")
+
+	got, err = p.String(`headers.content\.type`)
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, got, "application/json")
+
+	got, err = p.String(`['a[b]'][1]`)
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, got, "one")
+}
+
 func BenchmarkDotNotation(b *testing.B) {
 	tests := []string{
 		0:  "",
@@ -133,6 +426,9 @@ func BenchmarkDotNotation(b *testing.B) {
 		10: "near_earth_objects_estimated_diameter_meters_estimated_diameter_max",
 		11: "one",
 		12: "[123]",
+		13: `headers["content.type"].value`,
+		14: `example\.com.a\[b\]`,
+		15: "items[1:4].id",
 	}
 
 	d := DotNotation{}
@@ -146,3 +442,48 @@ func BenchmarkDotNotation(b *testing.B) {
 		})
 	}
 }
+
+func TestKeyComparable(t *testing.T) {
+	// Key has to stay comparable, so that it can be used with == and as a map key.
+	seen := map[Key]int{Field("a"): 1, Index(2): 2}
+	testingx.AssertEqual(t, seen[Field("a")], 1)
+	testingx.AssertEqual(t, seen[Index(2)], 2)
+	testingx.AssertEqual(t, Field("a") == Field("a"), true)
+	testingx.AssertEqual(t, Wildcard() == Wildcard(), true)
+
+	u := Union(Field("a"), Index(1))
+	testingx.AssertEqual(t, u == u, true) //nolint:gocritic // comparable.
+	testingx.AssertEqual(t, u.UnionKeys(), []Key{Field("a"), Index(1)})
+	testingx.AssertEqual(t, Field("a").UnionKeys(), []Key(nil))
+}
+
+func TestKeyEqual(t *testing.T) {
+	one, two := 1, 2
+	tests := map[string]struct {
+		a, b     Key
+		expected bool
+	}{
+		"same field":          {a: Field("a"), b: Field("a"), expected: true},
+		"different field":     {a: Field("a"), b: Field("b"), expected: false},
+		"field and index":     {a: Field("1"), b: Index(1), expected: false},
+		"same index":          {a: Index(-1), b: Index(-1), expected: true},
+		"wildcards":           {a: Wildcard(), b: Wildcard(), expected: true},
+		"same slice":          {a: Slice(&one, nil, 2), b: Slice(&one, nil, 2), expected: true},
+		"different slice":     {a: Slice(&one, nil, 2), b: Slice(&two, nil, 2), expected: false},
+		"slice and open end":  {a: Slice(&one, &two, 0), b: Slice(&one, nil, 0), expected: false},
+		"same union":          {a: Union(Field("a"), Index(1)), b: Union(Field("a"), Index(1)), expected: true},
+		"different union":     {a: Union(Field("a"), Index(1)), b: Union(Field("a"), Index(2)), expected: false},
+		"union members order": {a: Union(Field("a"), Field("b")), b: Union(Field("b"), Field("a")), expected: false},
+		"same filter":         {a: Filter("@.a"), b: Filter("@.a"), expected: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			testingx.AssertEqual(t, tc.a.Equal(tc.b), tc.expected)
+			testingx.AssertEqual(t, tc.b.Equal(tc.a), tc.expected)
+		})
+	}
+
+	// == compares the identity of the slice bounds and union members.
+	testingx.AssertEqual(t, Union(Field("a")) == Union(Field("a")), false)
+}
@@ -0,0 +1,317 @@
+package pick
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// PatchOp is the operation of a JSON Patch (RFC 6902) operation object.
+type PatchOp string
+
+const (
+	PatchOpAdd     PatchOp = "add"
+	PatchOpRemove  PatchOp = "remove"
+	PatchOpReplace PatchOp = "replace"
+	PatchOpMove    PatchOp = "move"
+	PatchOpCopy    PatchOp = "copy"
+	PatchOpTest    PatchOp = "test"
+)
+
+// jsonPointerEndOfArray is the JSON Pointer token that refers to the (nonexistent) element after the last element of an array.
+const jsonPointerEndOfArray = "-"
+
+// PatchOperation is a single operation of a JSON Patch (RFC 6902) document.
+// Path and From are JSON Pointers (RFC 6901). Value is used by add, replace and test operations.
+type PatchOperation struct {
+	Value any     `json:"value"`
+	Op    PatchOp `json:"op"`
+	Path  string  `json:"path"`
+	From  string  `json:"from"`
+}
+
+// MarshalJSON encodes the operation object with the members that its op requires: value for add, replace and test operations
+// (even if it is null) and from for move and copy operations (even if it is the root pointer "").
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	type operation struct {
+		Op    PatchOp `json:"op"`
+		Path  string  `json:"path"`
+		From  *string `json:"from,omitempty"`
+		Value *any    `json:"value,omitempty"`
+	}
+
+	m := operation{Op: o.Op, Path: o.Path}
+	switch o.Op {
+	case PatchOpAdd, PatchOpReplace, PatchOpTest:
+		m.Value = &o.Value
+	case PatchOpMove, PatchOpCopy:
+		m.From = &o.From
+	}
+
+	return json.Marshal(m)
+}
+
+// ParseJSONPatch parses a JSON Patch (RFC 6902) document, which is a JSON array of operation objects.
+// It validates that each operation has the members that its op requires.
+func ParseJSONPatch(js []byte) ([]PatchOperation, error) {
+	p, err := WrapJSON(js)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, isArray := p.Data().([]any); !isArray {
+		return nil, fmt.Errorf("%w: document is not an array", ErrInvalidPatch)
+	}
+
+	length, _ := p.Len("")
+	ops := make([]PatchOperation, 0, length)
+	err = Each(p, "", func(index int, item Picker, _ int) error {
+		op, err := parsePatchOperation(item)
+		if err != nil {
+			return &PatchError{inner: err, Index: index, Operation: op}
+		}
+		ops = append(ops, op)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+func parsePatchOperation(item Picker) (PatchOperation, error) {
+	var op PatchOperation
+
+	operation, err := item.String("op")
+	if err != nil {
+		return op, errors.Join(ErrInvalidPatch, err)
+	}
+	op.Op = PatchOp(operation)
+
+	op.Path, err = item.String("path")
+	if err != nil {
+		return op, errors.Join(ErrInvalidPatch, err)
+	}
+
+	switch op.Op {
+	case PatchOpAdd, PatchOpReplace, PatchOpTest:
+		op.Value, err = item.Any("value")
+		if err != nil {
+			return op, errors.Join(ErrInvalidPatch, err)
+		}
+	case PatchOpMove, PatchOpCopy:
+		op.From, err = item.String("from")
+		if err != nil {
+			return op, errors.Join(ErrInvalidPatch, err)
+		}
+	case PatchOpRemove:
+	default:
+		return op, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+	}
+
+	return op, nil
+}
+
+// ApplyPatch applies the JSON Patch (RFC 6902) operations to the wrapped data. The paths are JSON Pointers regardless of the notation of the picker.
+// The operations are applied to a deep copy of the data, which replaces the wrapped data only if all the operations succeed,
+// so either all the operations are applied or none.
+// Errors are returned as PatchError that wraps the error of the failing operation (usually a TraverseError).
+// It returns ErrSetNotSupported/ErrDeleteNotSupported if the traverser does not implement Setter/Deleter.
+func (p *Picker) ApplyPatch(ops []PatchOperation) error {
+	w := *p
+	w.notation = JSONPointerNotation{}
+	w.selectorCache = nil // the cache holds paths of the picker's notation.
+	w.data = cloneValue(p.data)
+
+	for i, op := range ops {
+		if err := w.applyPatchOperation(op); err != nil {
+			return &PatchError{inner: err, Index: i, Operation: op}
+		}
+	}
+
+	p.data = w.data
+	return nil
+}
+
+func (p *Picker) applyPatchOperation(op PatchOperation) error {
+	path, err := p.parse(op.Path)
+	if err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case PatchOpAdd:
+		return p.patchAdd(path, op.Value)
+
+	case PatchOpRemove:
+		return p.DeletePath(path)
+
+	case PatchOpReplace:
+		if _, err := p.Path(path); err != nil {
+			return err
+		}
+		return p.SetPath(path, op.Value)
+
+	case PatchOpMove:
+		from, err := p.parse(op.From)
+		if err != nil {
+			return err
+		}
+		if isPathPrefix(from, path) {
+			if len(from) == len(path) {
+				return nil
+			}
+			return NewTraverseError("error trying to move", path, len(path)-1, ErrMoveIntoItself).WithNotation(p.notation)
+		}
+		value, err := p.Path(from)
+		if err != nil {
+			return err
+		}
+		if err := p.DeletePath(from); err != nil {
+			return err
+		}
+		return p.patchAdd(path, value)
+
+	case PatchOpCopy:
+		from, err := p.parse(op.From)
+		if err != nil {
+			return err
+		}
+		value, err := p.Path(from)
+		if err != nil {
+			return err
+		}
+		return p.patchAdd(path, cloneValue(value))
+
+	case PatchOpTest:
+		value, err := p.Path(path)
+		if err != nil {
+			return err
+		}
+		if !valuesEqual(value, op.Value) {
+			return ErrPatchTestFailed
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// patchAdd implements the add operation: if the parent is an array, the value is inserted in the index (or appended if the last key is `-`),
+// otherwise the value is set. The parent has to exist.
+func (p *Picker) patchAdd(path []Key, value any) error {
+	if len(path) == 0 {
+		return p.SetPath(path, value)
+	}
+
+	parentPath, last := path[:len(path)-1], path[len(path)-1]
+	parent, err := p.Path(parentPath)
+	if err != nil {
+		return err
+	}
+
+	parentValue := reflect.ValueOf(parent)
+	if parentValue.Kind() != reflect.Slice {
+		return p.SetPath(path, value)
+	}
+
+	idx := last.Index
+	switch {
+	case last.IsField() && last.Name == jsonPointerEndOfArray:
+		idx = parentValue.Len()
+	case !last.IsIndex() || idx < 0 || idx > parentValue.Len():
+		return NewTraverseError("error trying to add", path, len(path)-1, ErrIndexOutOfRange).WithNotation(p.notation)
+	}
+
+	// insert a zero element and then set the value, so that it is converted to the element type by the traverser.
+	inserted := reflect.Append(parentValue, reflect.Zero(parentValue.Type().Elem()))
+	reflect.Copy(inserted.Slice(idx+1, inserted.Len()), inserted.Slice(idx, inserted.Len()-1))
+	inserted.Index(idx).SetZero()
+	if err := p.SetPath(parentPath, inserted.Interface()); err != nil {
+		return err
+	}
+
+	return p.SetPath(append(parentPath[:len(parentPath):len(parentPath)], Index(idx)), value)
+}
+
+// valuesEqual compares two values using the JSON semantics: numbers are equal if they have the same numeric value (regardless of their type),
+// arrays if they have equal elements in the same order and objects if they have the same keys with equal values.
+func valuesEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if fa, isNumber := numberAsFloat(va); isNumber {
+		fb, isNumber := numberAsFloat(vb)
+		return isNumber && fa == fb
+	}
+
+	switch va.Kind() {
+	case reflect.Slice, reflect.Array:
+		if vb.Kind() != reflect.Slice && vb.Kind() != reflect.Array {
+			return false
+		}
+		if va.Len() != vb.Len() {
+			return false
+		}
+		for i := range va.Len() {
+			if !valuesEqual(va.Index(i).Interface(), vb.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if vb.Kind() != reflect.Map || va.Len() != vb.Len() || !va.Type().Key().ConvertibleTo(vb.Type().Key()) {
+			return false
+		}
+		iter := va.MapRange()
+		for iter.Next() {
+			e := vb.MapIndex(iter.Key().Convert(vb.Type().Key()))
+			if !e.IsValid() || !valuesEqual(iter.Value().Interface(), e.Interface()) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func numberAsFloat(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// PatchError is returned when a JSON Patch operation fails. It holds the index and the operation that failed.
+type PatchError struct {
+	inner     error
+	Operation PatchOperation
+	Index     int
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch operation %d (%s %s): %s", e.Index, e.Operation.Op, e.Operation.Path, e.inner.Error())
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.inner
+}
+
+var (
+	ErrInvalidPatch    = errors.New("invalid patch")
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
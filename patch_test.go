@@ -0,0 +1,242 @@
+package pick
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPatch(t *testing.T) {
+	tests := map[string]struct {
+		document      string
+		patch         string
+		expected      string
+		errorAsserter tst.ErrorAssertionFunc
+	}{
+		"add object member": {
+			document:      `{"foo": "bar"}`,
+			patch:         `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			expected:      `{"baz": "qux", "foo": "bar"}`,
+			errorAsserter: tst.NoError(),
+		},
+		"add array element": {
+			document:      `{"foo": ["bar", "baz"]}`,
+			patch:         `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			expected:      `{"foo": ["bar", "qux", "baz"]}`,
+			errorAsserter: tst.NoError(),
+		},
+		"add to the end of an array": {
+			document:      `{"foo": ["bar"]}`,
+			patch:         `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			expected:      `{"foo": ["bar", ["abc", "def"]]}`,
+			errorAsserter: tst.NoError(),
+		},
+		"add null value": {
+			document:      `{"foo": "bar"}`,
+			patch:         `[{"op": "add", "path": "/baz", "value": null}]`,
+			expected:      `{"foo": "bar", "baz": null}`,
+			errorAsserter: tst.NoError(),
+		},
+		"add to nonexistent parent": {
+			document:      `{"foo": "bar"}`,
+			patch:         `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			expected:      `{"foo": "bar"}`,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"add out of range": {
+			document:      `{"foo": ["bar"]}`,
+			patch:         `[{"op": "add", "path": "/foo/2", "value": "qux"}]`,
+			expected:      `{"foo": ["bar"]}`,
+			errorAsserter: tst.ErrorIs(ErrIndexOutOfRange),
+		},
+		"replace the document": {
+			document:      `{"foo": "bar"}`,
+			patch:         `[{"op": "add", "path": "", "value": [1]}]`,
+			expected:      `[1]`,
+			errorAsserter: tst.NoError(),
+		},
+		"remove": {
+			document:      `{"baz": "qux", "foo": ["bar", "qux", "baz"]}`,
+			patch:         `[{"op": "remove", "path": "/baz"}, {"op": "remove", "path": "/foo/1"}]`,
+			expected:      `{"foo": ["bar", "baz"]}`,
+			errorAsserter: tst.NoError(),
+		},
+		"replace": {
+			document:      `{"baz": "qux", "foo": "bar"}`,
+			patch:         `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			expected:      `{"baz": "boo", "foo": "bar"}`,
+			errorAsserter: tst.NoError(),
+		},
+		"replace nonexistent": {
+			document:      `{"foo": "bar"}`,
+			patch:         `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			expected:      `{"foo": "bar"}`,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"move": {
+			document:      `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch:         `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			expected:      `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+			errorAsserter: tst.NoError(),
+		},
+		"move array element": {
+			document:      `{"foo": ["all", "grass", "cows", "eat"]}`,
+			patch:         `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			expected:      `{"foo": ["all", "cows", "eat", "grass"]}`,
+			errorAsserter: tst.NoError(),
+		},
+		"move into itself": {
+			document:      `{"foo": {"bar": 1}}`,
+			patch:         `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`,
+			expected:      `{"foo": {"bar": 1}}`,
+			errorAsserter: tst.ErrorIs(ErrMoveIntoItself),
+		},
+		"copy": {
+			document:      `{"foo": {"bar": [1]}}`,
+			patch:         `[{"op": "copy", "from": "/foo/bar", "path": "/baz"}, {"op": "add", "path": "/baz/0", "value": 0}]`,
+			expected:      `{"foo": {"bar": [1]}, "baz": [0, 1]}`,
+			errorAsserter: tst.NoError(),
+		},
+		"test": {
+			document:      `{"baz": "qux", "foo": ["a", 2, "c"], "obj": {"a": [1.0]}}`,
+			patch:         `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}, {"op": "test", "path": "/obj", "value": {"a": [1]}}]`,
+			expected:      `{"baz": "qux", "foo": ["a", 2, "c"], "obj": {"a": [1]}}`,
+			errorAsserter: tst.NoError(),
+		},
+		"failed test reverts the previous operations": {
+			document:      `{"baz": "qux", "foo": ["a"]}`,
+			patch:         `[{"op": "remove", "path": "/foo/0"}, {"op": "replace", "path": "/baz", "value": "x"}, {"op": "test", "path": "/baz", "value": "qux"}]`,
+			expected:      `{"baz": "qux", "foo": ["a"]}`,
+			errorAsserter: tst.ErrorIs(ErrPatchTestFailed),
+		},
+		"escaped path": {
+			document:      `{"/": 1, "m~n": 2}`,
+			patch:         `[{"op": "move", "from": "/~1", "path": "/a"}, {"op": "remove", "path": "/m~0n"}]`,
+			expected:      `{"a": 1}`,
+			errorAsserter: tst.NoError(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := WrapJSON([]byte(tc.document))
+			require.NoError(t, err)
+			expected, err := WrapJSON([]byte(tc.expected))
+			require.NoError(t, err)
+
+			ops, err := ParseJSONPatch([]byte(tc.patch))
+			require.NoError(t, err)
+
+			err = p.ApplyPatch(ops)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, p.Data(), expected.Data())
+		})
+	}
+}
+
+func TestApplyPatchTypedData(t *testing.T) {
+	type user struct {
+		Tags []int
+		Name string
+	}
+
+	p := Wrap(&user{Name: "alice", Tags: []int{1, 3}})
+	err := p.ApplyPatch([]PatchOperation{
+		{Op: PatchOpAdd, Path: "/Tags/1", Value: "2"},
+		{Op: PatchOpReplace, Path: "/Name", Value: "bob"},
+		{Op: PatchOpTest, Path: "/Tags", Value: []any{1.0, 2.0, 3.0}},
+	})
+	require.NoError(t, err)
+	testingx.AssertEqual(t, p.Data(), any(&user{Name: "bob", Tags: []int{1, 2, 3}}))
+
+	err = p.ApplyPatch([]PatchOperation{
+		{Op: PatchOpRemove, Path: "/Tags/0"},
+		{Op: PatchOpRemove, Path: "/Tags/5"},
+	})
+	var pe *PatchError
+	require.True(t, errors.As(err, &pe))
+	testingx.AssertEqual(t, pe.Index, 1)
+	testingx.AssertEqual(t, pe.Operation, PatchOperation{Op: PatchOpRemove, Path: "/Tags/5"})
+	testingx.AssertEqual(t, err.Error(), "patch operation 1 (remove /Tags/5): selector: /Tags/5 : error trying to delete: field not found: index out of range")
+	testingx.AssertEqual(t, p.Data(), any(&user{Name: "bob", Tags: []int{1, 2, 3}}))
+}
+
+func TestParseJSONPatch(t *testing.T) {
+	ops, err := ParseJSONPatch([]byte(`[
+		{"op": "add", "path": "/a", "value": {"b": null}},
+		{"op": "remove", "path": "/a/b"},
+		{"op": "copy", "from": "/a", "path": "/c"},
+		{"op": "test", "path": "/c", "value": null}
+	]`))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, ops, []PatchOperation{
+		{Op: PatchOpAdd, Path: "/a", Value: map[string]any{"b": nil}},
+		{Op: PatchOpRemove, Path: "/a/b"},
+		{Op: PatchOpCopy, Path: "/c", From: "/a"},
+		{Op: PatchOpTest, Path: "/c"},
+	})
+
+	errorCases := map[string]string{
+		"not an array":  `{"op": "remove", "path": "/a"}`,
+		"missing op":    `[{"path": "/a"}]`,
+		"unknown op":    `[{"op": "merge", "path": "/a"}]`,
+		"missing path":  `[{"op": "remove"}]`,
+		"missing value": `[{"op": "add", "path": "/a"}]`,
+		"missing from":  `[{"op": "move", "path": "/a"}]`,
+	}
+	for name, patch := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseJSONPatch([]byte(patch))
+			tst.ErrorIs(ErrInvalidPatch)(t, err)
+		})
+	}
+
+	_, err = ParseJSONPatch([]byte(`[`))
+	require.Error(t, err)
+}
+
+func TestPatchOperationMarshalJSON(t *testing.T) {
+	ops := []PatchOperation{
+		{Op: PatchOpAdd, Path: "/a", Value: nil},
+		{Op: PatchOpRemove, Path: "/b"},
+		{Op: PatchOpReplace, Path: "/c", Value: map[string]any{"d": nil}},
+		{Op: PatchOpMove, Path: "/e", From: ""},
+		{Op: PatchOpCopy, Path: "/f", From: "/c"},
+		{Op: PatchOpTest, Path: "/c/d", Value: nil},
+	}
+
+	js, err := json.Marshal(ops)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, string(js), `[`+
+		`{"op":"add","path":"/a","value":null},`+
+		`{"op":"remove","path":"/b"},`+
+		`{"op":"replace","path":"/c","value":{"d":null}},`+
+		`{"op":"move","path":"/e","from":""},`+
+		`{"op":"copy","path":"/f","from":"/c"},`+
+		`{"op":"test","path":"/c/d","value":null}]`)
+
+	parsed, err := ParseJSONPatch(js)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, parsed, ops)
+
+	t.Run("diff round trip", func(t *testing.T) {
+		a, err := WrapJSON([]byte(`{"a": 1, "b": [1]}`))
+		require.NoError(t, err)
+		b, err := WrapJSON([]byte(`{"a": null, "b": [1, null], "c": null}`))
+		require.NoError(t, err)
+
+		changes, err := Diff(a, b)
+		require.NoError(t, err)
+		js, err := json.Marshal(AsPatch(changes))
+		require.NoError(t, err)
+		ops, err := ParseJSONPatch(js)
+		require.NoError(t, err)
+
+		require.NoError(t, a.ApplyPatch(ops))
+		testingx.AssertEqual(t, a.Data(), b.Data())
+	})
+}
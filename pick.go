@@ -6,6 +6,9 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/moukoublen/pick/iter"
@@ -65,22 +68,60 @@ func Wrap(data any) Picker {
 	return NewPicker(data, NewDefaultTraverser(converter), converter, DotNotation{})
 }
 
+// Picker wraps data and picks values out of it using selectors.
+//
+// The read methods (e.g. Any, Int, Each) have value receivers, while the methods that modify the data
+// (Set, Delete, Move, Copy, ApplyPatch and their Path versions) have pointer receivers, since they might replace the wrapped data
+// (e.g. a nil data or a slice that grows). So a Picker that is going to be modified should be held and passed by pointer:
+// a copy of a Picker shares the wrapped data at the time of the copy, but it does not see the data that replaces it afterwards.
+// The mutators cannot be called on non addressable Picker values (e.g. map elements or values held in an interface).
 type Picker struct {
-	data      any
-	traverser Traverser
-	Converter Converter
-	notation  Notation
+	data          any
+	traverser     Traverser
+	Converter     Converter
+	notation      Notation
+	selectorCache *SelectorCache
+}
+
+// PickerOption configures optional behavior of a Picker.
+type PickerOption func(p *Picker)
+
+// WithSelectorCache attaches a cache of parsed selectors to the picker (see SelectorCache).
+// The cache is shared by all the pickers that are produced by `Wrap`, and it can be shared by pickers with different notations.
+// A nil cache disables caching.
+func WithSelectorCache(cache *SelectorCache) PickerOption {
+	return func(p *Picker) {
+		p.selectorCache = cache
+	}
 }
 
-func NewPicker(data any, t Traverser, c Converter, n Notation) Picker {
-	return Picker{
+func NewPicker(data any, t Traverser, c Converter, n Notation, opts ...PickerOption) Picker {
+	p := Picker{
 		data:      data,
 		traverser: t,
 		Converter: c,
 		notation:  n,
 	}
+
+	for _, o := range opts {
+		o(&p)
+	}
+
+	return p
+}
+
+// parse parses the selector using the picker's notation, through the selector cache if one is attached.
+func (p Picker) parse(selector string) ([]Key, error) {
+	if p.selectorCache != nil {
+		return p.selectorCache.parse(p.notation, selector)
+	}
+
+	return p.notation.Parse(selector)
 }
 
+// SelectorCache returns the selector cache that is attached to the picker, or nil.
+func (p Picker) SelectorCache() *SelectorCache { return p.selectorCache }
+
 func (p Picker) Data() any { return p.data }
 
 func (p Picker) Relaxed(onErr ...ErrorGatherer) RelaxedAPI {
@@ -88,7 +129,7 @@ func (p Picker) Relaxed(onErr ...ErrorGatherer) RelaxedAPI {
 }
 
 func (p Picker) Any(selector string) (any, error) {
-	path, err := p.notation.Parse(selector)
+	path, err := p.parse(selector)
 	if err != nil {
 		return nil, err
 	}
@@ -97,11 +138,228 @@ func (p Picker) Any(selector string) (any, error) {
 }
 
 func (p Picker) Path(path []Key) (any, error) {
-	return p.traverser.Retrieve(p.data, path)
+	item, err := p.traverser.Retrieve(p.data, path)
+	if err != nil {
+		// render the traversed path using the picker's notation.
+		var te *TraverseError
+		if errors.As(err, &te) {
+			te.WithNotation(p.notation)
+		}
+	}
+
+	return item, err
+}
+
+// Matches parses the selector and returns every result together with its concrete path.
+// It is mostly useful for selectors that select multiple elements (e.g. `..id` or `items[*].id`).
+// If the traverser does not implement MatchTraverser, the single result of the traversal is returned.
+func (p Picker) Matches(selector string) ([]Match, error) {
+	path, err := p.parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	mt, is := p.traverser.(MatchTraverser)
+	if !is {
+		item, err := p.Path(path)
+		if err != nil {
+			return nil, err
+		}
+		return []Match{{Value: item, Path: path}}, nil
+	}
+
+	matches, err := mt.RetrieveMatches(p.data, path)
+	if err != nil {
+		var te *TraverseError
+		if errors.As(err, &te) {
+			te.WithNotation(p.notation)
+		}
+	}
+
+	return matches, err
+}
+
+// Set parses the selector and sets the value in the wrapped data. Intermediate maps/slices are created if they do not exist,
+// slices grow if the index is after the end and negative indices are relative to the end.
+// The wrapped data might be replaced (e.g. if it was nil or if it is a slice that had to grow), so the picker is a pointer receiver.
+func (p *Picker) Set(selector string, value any) error {
+	path, err := p.parse(selector)
+	if err != nil {
+		return err
+	}
+
+	return p.SetPath(path, value)
+}
+
+// SetPath sets the value in the path of the wrapped data. See [Picker.Set].
+// It returns ErrSetNotSupported if the traverser does not implement Setter.
+func (p *Picker) SetPath(path []Key, value any) error {
+	s, is := p.traverser.(Setter)
+	if !is {
+		return ErrSetNotSupported
+	}
+
+	data, err := s.Set(p.data, path, value)
+	if err != nil {
+		var te *TraverseError
+		if errors.As(err, &te) {
+			te.WithNotation(p.notation)
+		}
+		return err
+	}
+
+	p.data = data
+	return nil
+}
+
+// Delete parses the selector and removes the value from the wrapped data. Map keys are deleted and slice elements are spliced
+// (the following elements are shifted), while struct fields and array elements are reset to their zero value.
+// The wrapped data is modified in place whenever possible (maps, slices, pointers).
+func (p *Picker) Delete(selector string) error {
+	path, err := p.parse(selector)
+	if err != nil {
+		return err
+	}
+
+	return p.DeletePath(path)
+}
+
+// DeletePath removes the value of the path from the wrapped data. See [Picker.Delete].
+// It returns ErrDeleteNotSupported if the traverser does not implement Deleter.
+func (p *Picker) DeletePath(path []Key) error {
+	d, is := p.traverser.(Deleter)
+	if !is {
+		return ErrDeleteNotSupported
+	}
+
+	data, err := d.Delete(p.data, path)
+	if err != nil {
+		var te *TraverseError
+		if errors.As(err, &te) {
+			te.WithNotation(p.notation)
+		}
+		return err
+	}
+
+	p.data = data
+	return nil
+}
+
+// Move parses both selectors and moves the value from the first path to the second one (e.g. in order to rename a field).
+// The value is first removed and then set, so indices of the destination are resolved after the removal.
+// Moving a value into one of its own children returns an error. If the value cannot be set to the destination,
+// it is put back to its original position and the error is returned.
+func (p *Picker) Move(from, to string) error {
+	fromPath, err := p.parse(from)
+	if err != nil {
+		return err
+	}
+	toPath, err := p.parse(to)
+	if err != nil {
+		return err
+	}
+
+	return p.MovePath(fromPath, toPath)
+}
+
+// MovePath moves the value from a path to another. See [Picker.Move].
+func (p *Picker) MovePath(from, to []Key) error {
+	if isPathPrefix(from, to) {
+		if len(from) == len(to) {
+			return nil
+		}
+		return NewTraverseError("error trying to move", to, len(to)-1, ErrMoveIntoItself).WithNotation(p.notation)
+	}
+
+	value, err := p.Path(from)
+	if err != nil {
+		return err
+	}
+
+	// the position of the value, with the last key resolved to a non negative index if the parent is a slice, so that the value
+	// can be put back if setting it fails after the removal.
+	restore := p.concretePath(from)
+	if err := p.DeletePath(from); err != nil {
+		return err
+	}
+	if err := p.SetPath(to, value); err != nil {
+		if restoreErr := p.patchAdd(restore, value); restoreErr != nil {
+			return errors.Join(err, restoreErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// concretePath returns the path with its last key resolved to a non negative index if it addresses an element of a slice.
+func (p *Picker) concretePath(path []Key) []Key {
+	parentPath, last := path[:len(path)-1], path[len(path)-1]
+	parent, err := p.Path(parentPath)
+	if err != nil {
+		return path
+	}
+
+	parentValue := reflect.ValueOf(parent)
+	if parentValue.Kind() != reflect.Slice {
+		return path
+	}
+
+	index := last.Index
+	if last.IsField() {
+		i, err := strconv.Atoi(last.Name)
+		if err != nil {
+			return path
+		}
+		index = i
+	}
+
+	return append(slices.Clip(parentPath), Index(relativeIndex(index, parentValue.Len())))
+}
+
+// Copy parses both selectors and sets a deep copy of the value of the first path to the second one.
+func (p *Picker) Copy(from, to string) error {
+	fromPath, err := p.parse(from)
+	if err != nil {
+		return err
+	}
+	toPath, err := p.parse(to)
+	if err != nil {
+		return err
+	}
+
+	return p.CopyPath(fromPath, toPath)
+}
+
+// CopyPath sets a deep copy of the value of a path to another. See [Picker.Copy].
+func (p *Picker) CopyPath(from, to []Key) error {
+	value, err := p.Path(from)
+	if err != nil {
+		return err
+	}
+
+	return p.SetPath(to, cloneValue(value))
+}
+
+// isPathPrefix returns true if the prefix consists of the first field and index keys of the path.
+func isPathPrefix(prefix, path []Key) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, k := range prefix {
+		if !k.IsField() && !k.IsIndex() {
+			return false
+		}
+		if !k.Equal(path[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (p Picker) Len(selector string) (int, error) {
-	path, err := p.notation.Parse(selector)
+	path, err := p.parse(selector)
 	if err != nil {
 		return 0, err
 	}
@@ -280,9 +538,9 @@ func (p Picker) DurationSliceWithConfig(config DurationConvertConfig, selector s
 	})
 }
 
-// Wrap returns a new Picker using the same traverser, converter and notation.
+// Wrap returns a new Picker using the same traverser, converter, notation and selector cache.
 func (p Picker) Wrap(data any) Picker {
-	return NewPicker(data, p.traverser, p.Converter, p.notation)
+	return NewPicker(data, p.traverser, p.Converter, p.notation, WithSelectorCache(p.selectorCache))
 }
 
 // Relaxed API
@@ -464,7 +722,7 @@ func (a RelaxedAPI) DurationSliceWithConfig(config DurationConvertConfig, select
 }
 
 func (a RelaxedAPI) Wrap(data any) RelaxedAPI {
-	return NewPicker(data, a.traverser, a.Converter, a.notation).Relaxed(a.errorGatherers...)
+	return a.Picker.Wrap(data).Relaxed(a.errorGatherers...)
 }
 
 //nolint:ireturn
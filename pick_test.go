@@ -818,3 +818,124 @@ func TestHTTP(t *testing.T) {
 		require.Nil(t, p.Data())
 	})
 }
+
+func TestWildcard(t *testing.T) {
+	t.Parallel()
+
+	p, err := WrapJSON([]byte(`{
+		"items": [{"id": 1}, {"id": 2}, {"id": "3"}],
+		"users": {"b": {"email": "b@x"}, "a": {"email": "a@x"}}
+	}`))
+	require.NoError(t, err)
+
+	ids, err := p.Int64Slice("items[*].id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, ids, []int64{1, 2, 3})
+
+	emails, err := p.StringSlice("users.*.email")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, emails, []string{"a@x", "b@x"})
+
+	l, err := p.Len("items[*]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, l, 3)
+
+	testingx.AssertEqual(t, p.Relaxed().StringSlice("items[*].missing"), []string(nil))
+}
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+
+	p, err := WrapJSON([]byte(`{
+		"items": [0, 1, 2, 3, 4, 5],
+		"users": [{"name": "a"}, {"name": "b"}, {"name": "c"}]
+	}`))
+	require.NoError(t, err)
+
+	got, err := p.IntSlice("items[1:4]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []int{1, 2, 3})
+
+	got, err = p.IntSlice("items[-3:]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []int{3, 4, 5})
+
+	got, err = p.IntSlice("items[::2]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []int{0, 2, 4})
+
+	got, err = p.IntSlice("items[::-2]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []int{5, 3, 1})
+
+	names, err := p.StringSlice("users[:2].name")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, names, []string{"a", "b"})
+
+	l, err := p.Len("items[10:]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, l, 0)
+
+	typed := Wrap(map[string]any{"arr": [4]string{"w", "x", "y", "z"}})
+	names, err = typed.StringSlice("arr[1:3]")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, names, []string{"x", "y"})
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	p, err := WrapJSON([]byte(`{
+		"user": {"name": "alice", "email": "alice@x", "age": 30},
+		"items": [{"id": "a"}, {"id": "b"}, {"id": "c"}, {"id": "d"}, {"id": "e"}, {"id": "f"}]
+	}`))
+	require.NoError(t, err)
+
+	got, err := p.StringSlice(`user['name','email']`)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []string{"alice", "alice@x"})
+
+	got, err = p.StringSlice(`user["email","missing","name"]`)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []string{"alice@x", "alice"})
+
+	got, err = p.StringSlice("items[0,2,5].id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []string{"a", "c", "f"})
+
+	got, err = p.StringSlice("items[-1,0:2,9].id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, []string{"f", "a", "b"})
+
+	matches, err := p.Matches("items[-1,1].id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, matches, []Match{
+		{Value: "f", Path: []Key{Field("items"), Index(5), Field("id")}},
+		{Value: "b", Path: []Key{Field("items"), Index(1), Field("id")}},
+	})
+}
+
+func TestRecursiveDescent(t *testing.T) {
+	t.Parallel()
+
+	p, err := WrapJSON([]byte(`{
+		"id": "root",
+		"data": {"order": {"id": "o-1", "lines": [{"id": "l-1"}, {"id": "l-2", "meta": {"id": "m-1"}}]}}
+	}`))
+	require.NoError(t, err)
+
+	ids, err := p.StringSlice("..id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, ids, []string{"root", "o-1", "l-1", "l-2", "m-1"})
+
+	ids, err = p.StringSlice("data.order.lines..id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, ids, []string{"l-1", "l-2", "m-1"})
+
+	matches, err := p.Matches("data..lines[-1].id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, matches, []Match{
+		{Value: "l-2", Path: []Key{Field("data"), Field("order"), Field("lines"), Index(1), Field("id")}},
+	})
+	testingx.AssertEqual(t, DotNotation{}.Format(matches[0].Path...), "data.order.lines[1].id")
+}
@@ -38,7 +38,7 @@ func EachField(p Picker, selector string, operation func(field string, value Pic
 		return err
 	}
 
-	return iter.ForEachField(
+	return p.eachField(
 		item,
 		func(item any, meta iter.FieldOpMeta) error {
 			return operation(meta.Name, p.Wrap(item), meta.Length)
@@ -171,7 +171,7 @@ func RelaxedEachField(a RelaxedAPI, selector string, operation func(field string
 		return
 	}
 
-	err = iter.ForEachField(item, func(item any, meta iter.FieldOpMeta) error {
+	err = a.eachField(item, func(item any, meta iter.FieldOpMeta) error {
 		opErr := operation(meta.Name, a.Wrap(item), meta.Length)
 		if opErr != nil {
 			path = append(path, Field(meta.Name))
@@ -239,7 +239,7 @@ func RelaxedFlatMap[Output any](a RelaxedAPI, selector string, transform func(Re
 func RelaxedPath[Output any](a RelaxedAPI, path ...Key) Output { //nolint:ireturn
 	converted, err := Path[Output](a.Picker, path...)
 	if err != nil {
-		selector := DotNotation{}.Format(path...)
+		selector := a.notation.Format(path...)
 		a.gather(selector, err)
 	}
 	return converted
@@ -282,7 +282,7 @@ func flatten[Output any](doubleSlice [][]Output) []Output {
 }
 
 func parseSelectorAndTraverse(p Picker, selector string) (any, []Key, error) {
-	path, err := p.notation.Parse(selector)
+	path, err := p.parse(selector)
 	if err != nil {
 		return nil, path, err
 	}
@@ -0,0 +1,535 @@
+package pick
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Selector is a precompiled selector. It holds the parsed path together with the original selector string,
+// so that it can be used in hot paths without parsing (and allocating) on each call, while errors still render the original selector.
+// A Selector is immutable and safe for concurrent use.
+type Selector struct {
+	raw  string
+	path []Key
+}
+
+// Compile parses the selector using the default dot notation.
+func Compile(selector string) (Selector, error) {
+	return CompileWithNotation(DotNotation{}, selector)
+}
+
+// MustCompile is like Compile but panics if the selector cannot be parsed.
+// It simplifies the initialization of global variables holding compiled selectors.
+func MustCompile(selector string) Selector {
+	s, err := Compile(selector)
+	if err != nil {
+		panic(fmt.Sprintf("pick: Compile(%q): %s", selector, err.Error()))
+	}
+
+	return s
+}
+
+// CompileWithNotation parses the selector using the given notation.
+func CompileWithNotation(n Notation, selector string) (Selector, error) {
+	path, err := n.Parse(selector)
+	if err != nil {
+		return Selector{}, err
+	}
+
+	// clip, so that appending to the path (e.g. in error reporting) never writes to the shared backing array.
+	return Selector{raw: selector, path: slices.Clip(path)}, nil
+}
+
+// String returns the original selector string.
+func (s Selector) String() string { return s.raw }
+
+// Path returns a copy of the parsed path.
+func (s Selector) Path() []Key { return slices.Clone(s.path) }
+
+// Compile parses the selector using the picker's notation.
+func (p Picker) Compile(selector string) (Selector, error) {
+	return CompileWithNotation(p.notation, selector)
+}
+
+// AnyCompiled is the version of Any that uses a precompiled selector.
+func (p Picker) AnyCompiled(s Selector) (any, error) {
+	return p.Path(s.path)
+}
+
+// AnyCompiled is the version of Any that uses a precompiled selector. Version of [Picker.AnyCompiled] that uses RelaxedAPI.
+func (a RelaxedAPI) AnyCompiled(s Selector) any {
+	item, err := a.Picker.AnyCompiled(s)
+	if err != nil {
+		a.gather(s.raw, err)
+	}
+
+	return item
+}
+
+// GetCompiled is the version of [Get] that uses a precompiled selector.
+func GetCompiled[Output any](p Picker, s Selector) (Output, error) { //nolint:ireturn
+	var defaultValue Output
+
+	item, err := p.AnyCompiled(s)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	return convertAs(p.Converter, item, defaultValue)
+}
+
+// OrDefaultCompiled is the version of [OrDefault] that uses a precompiled selector.
+func OrDefaultCompiled[Output any](p Picker, s Selector, defaultValue Output) (Output, error) { //nolint:ireturn
+	item, err := p.AnyCompiled(s)
+	if err != nil {
+		if errors.Is(err, ErrFieldNotFound) {
+			return defaultValue, nil
+		}
+
+		return defaultValue, err
+	}
+
+	return convertAs(p.Converter, item, defaultValue)
+}
+
+// RelaxedGetCompiled is the version of [RelaxedGet] that uses a precompiled selector.
+func RelaxedGetCompiled[Output any](a RelaxedAPI, s Selector) Output { //nolint:ireturn
+	item, err := GetCompiled[Output](a.Picker, s)
+	if err != nil {
+		a.gather(s.raw, err)
+	}
+
+	return item
+}
+
+// RelaxedOrDefaultCompiled is the version of [RelaxedOrDefault] that uses a precompiled selector.
+func RelaxedOrDefaultCompiled[Output any](a RelaxedAPI, s Selector, defaultValue Output) Output { //nolint:ireturn
+	item, err := OrDefaultCompiled(a.Picker, s, defaultValue)
+	if err != nil {
+		a.gather(s.raw, err)
+	}
+
+	return item
+}
+
+// pickCompiled is the version of pickSelector that uses a precompiled selector.
+//
+//nolint:ireturn
+func pickCompiled[Output any](p Picker, s Selector, convertFn func(any) (Output, error)) (Output, error) {
+	item, err := p.AnyCompiled(s)
+	if err != nil {
+		var o Output
+		return o, err
+	}
+
+	return convertFn(item)
+}
+
+// pickRelaxedCompiled is the version of pickRelaxed that uses a precompiled selector.
+//
+//nolint:ireturn
+func pickRelaxedCompiled[Output any](a RelaxedAPI, s Selector, convertFn func(any) (Output, error)) Output {
+	converted, err := pickCompiled(a.Picker, s, convertFn)
+	if err != nil {
+		a.gather(s.raw, err)
+	}
+	return converted
+}
+
+// BoolCompiled is the version of [Picker.Bool] that uses a precompiled selector.
+func (p Picker) BoolCompiled(s Selector) (bool, error) {
+	return pickCompiled(p, s, p.Converter.AsBool)
+}
+
+// BoolSliceCompiled is the version of [Picker.BoolSlice] that uses a precompiled selector.
+func (p Picker) BoolSliceCompiled(s Selector) ([]bool, error) {
+	return pickCompiled(p, s, p.Converter.AsBoolSlice)
+}
+
+// ByteCompiled is the version of [Picker.Byte] that uses a precompiled selector.
+func (p Picker) ByteCompiled(s Selector) (byte, error) {
+	return pickCompiled(p, s, p.Converter.AsByte)
+}
+
+// ByteSliceCompiled is the version of [Picker.ByteSlice] that uses a precompiled selector.
+func (p Picker) ByteSliceCompiled(s Selector) ([]byte, error) {
+	return pickCompiled(p, s, p.Converter.AsByteSlice)
+}
+
+// Float32Compiled is the version of [Picker.Float32] that uses a precompiled selector.
+func (p Picker) Float32Compiled(s Selector) (float32, error) {
+	return pickCompiled(p, s, p.Converter.AsFloat32)
+}
+
+// Float32SliceCompiled is the version of [Picker.Float32Slice] that uses a precompiled selector.
+func (p Picker) Float32SliceCompiled(s Selector) ([]float32, error) {
+	return pickCompiled(p, s, p.Converter.AsFloat32Slice)
+}
+
+// Float64Compiled is the version of [Picker.Float64] that uses a precompiled selector.
+func (p Picker) Float64Compiled(s Selector) (float64, error) {
+	return pickCompiled(p, s, p.Converter.AsFloat64)
+}
+
+// Float64SliceCompiled is the version of [Picker.Float64Slice] that uses a precompiled selector.
+func (p Picker) Float64SliceCompiled(s Selector) ([]float64, error) {
+	return pickCompiled(p, s, p.Converter.AsFloat64Slice)
+}
+
+// IntCompiled is the version of [Picker.Int] that uses a precompiled selector.
+func (p Picker) IntCompiled(s Selector) (int, error) {
+	return pickCompiled(p, s, p.Converter.AsInt)
+}
+
+// IntSliceCompiled is the version of [Picker.IntSlice] that uses a precompiled selector.
+func (p Picker) IntSliceCompiled(s Selector) ([]int, error) {
+	return pickCompiled(p, s, p.Converter.AsIntSlice)
+}
+
+// Int8Compiled is the version of [Picker.Int8] that uses a precompiled selector.
+func (p Picker) Int8Compiled(s Selector) (int8, error) {
+	return pickCompiled(p, s, p.Converter.AsInt8)
+}
+
+// Int8SliceCompiled is the version of [Picker.Int8Slice] that uses a precompiled selector.
+func (p Picker) Int8SliceCompiled(s Selector) ([]int8, error) {
+	return pickCompiled(p, s, p.Converter.AsInt8Slice)
+}
+
+// Int16Compiled is the version of [Picker.Int16] that uses a precompiled selector.
+func (p Picker) Int16Compiled(s Selector) (int16, error) {
+	return pickCompiled(p, s, p.Converter.AsInt16)
+}
+
+// Int16SliceCompiled is the version of [Picker.Int16Slice] that uses a precompiled selector.
+func (p Picker) Int16SliceCompiled(s Selector) ([]int16, error) {
+	return pickCompiled(p, s, p.Converter.AsInt16Slice)
+}
+
+// Int32Compiled is the version of [Picker.Int32] that uses a precompiled selector.
+func (p Picker) Int32Compiled(s Selector) (int32, error) {
+	return pickCompiled(p, s, p.Converter.AsInt32)
+}
+
+// Int32SliceCompiled is the version of [Picker.Int32Slice] that uses a precompiled selector.
+func (p Picker) Int32SliceCompiled(s Selector) ([]int32, error) {
+	return pickCompiled(p, s, p.Converter.AsInt32Slice)
+}
+
+// Int64Compiled is the version of [Picker.Int64] that uses a precompiled selector.
+func (p Picker) Int64Compiled(s Selector) (int64, error) {
+	return pickCompiled(p, s, p.Converter.AsInt64)
+}
+
+// Int64SliceCompiled is the version of [Picker.Int64Slice] that uses a precompiled selector.
+func (p Picker) Int64SliceCompiled(s Selector) ([]int64, error) {
+	return pickCompiled(p, s, p.Converter.AsInt64Slice)
+}
+
+// UintCompiled is the version of [Picker.Uint] that uses a precompiled selector.
+func (p Picker) UintCompiled(s Selector) (uint, error) {
+	return pickCompiled(p, s, p.Converter.AsUint)
+}
+
+// UintSliceCompiled is the version of [Picker.UintSlice] that uses a precompiled selector.
+func (p Picker) UintSliceCompiled(s Selector) ([]uint, error) {
+	return pickCompiled(p, s, p.Converter.AsUintSlice)
+}
+
+// Uint8Compiled is the version of [Picker.Uint8] that uses a precompiled selector.
+func (p Picker) Uint8Compiled(s Selector) (uint8, error) {
+	return pickCompiled(p, s, p.Converter.AsUint8)
+}
+
+// Uint8SliceCompiled is the version of [Picker.Uint8Slice] that uses a precompiled selector.
+func (p Picker) Uint8SliceCompiled(s Selector) ([]uint8, error) {
+	return pickCompiled(p, s, p.Converter.AsUint8Slice)
+}
+
+// Uint16Compiled is the version of [Picker.Uint16] that uses a precompiled selector.
+func (p Picker) Uint16Compiled(s Selector) (uint16, error) {
+	return pickCompiled(p, s, p.Converter.AsUint16)
+}
+
+// Uint16SliceCompiled is the version of [Picker.Uint16Slice] that uses a precompiled selector.
+func (p Picker) Uint16SliceCompiled(s Selector) ([]uint16, error) {
+	return pickCompiled(p, s, p.Converter.AsUint16Slice)
+}
+
+// Uint32Compiled is the version of [Picker.Uint32] that uses a precompiled selector.
+func (p Picker) Uint32Compiled(s Selector) (uint32, error) {
+	return pickCompiled(p, s, p.Converter.AsUint32)
+}
+
+// Uint32SliceCompiled is the version of [Picker.Uint32Slice] that uses a precompiled selector.
+func (p Picker) Uint32SliceCompiled(s Selector) ([]uint32, error) {
+	return pickCompiled(p, s, p.Converter.AsUint32Slice)
+}
+
+// Uint64Compiled is the version of [Picker.Uint64] that uses a precompiled selector.
+func (p Picker) Uint64Compiled(s Selector) (uint64, error) {
+	return pickCompiled(p, s, p.Converter.AsUint64)
+}
+
+// Uint64SliceCompiled is the version of [Picker.Uint64Slice] that uses a precompiled selector.
+func (p Picker) Uint64SliceCompiled(s Selector) ([]uint64, error) {
+	return pickCompiled(p, s, p.Converter.AsUint64Slice)
+}
+
+// StringCompiled is the version of [Picker.String] that uses a precompiled selector.
+func (p Picker) StringCompiled(s Selector) (string, error) {
+	return pickCompiled(p, s, p.Converter.AsString)
+}
+
+// StringSliceCompiled is the version of [Picker.StringSlice] that uses a precompiled selector.
+func (p Picker) StringSliceCompiled(s Selector) ([]string, error) {
+	return pickCompiled(p, s, p.Converter.AsStringSlice)
+}
+
+// TimeCompiled is the version of [Picker.Time] that uses a precompiled selector.
+func (p Picker) TimeCompiled(s Selector) (time.Time, error) {
+	return pickCompiled(p, s, p.Converter.AsTime)
+}
+
+// TimeSliceCompiled is the version of [Picker.TimeSlice] that uses a precompiled selector.
+func (p Picker) TimeSliceCompiled(s Selector) ([]time.Time, error) {
+	return pickCompiled(p, s, p.Converter.AsTimeSlice)
+}
+
+// DurationCompiled is the version of [Picker.Duration] that uses a precompiled selector.
+func (p Picker) DurationCompiled(s Selector) (time.Duration, error) {
+	return pickCompiled(p, s, p.Converter.AsDuration)
+}
+
+// DurationSliceCompiled is the version of [Picker.DurationSlice] that uses a precompiled selector.
+func (p Picker) DurationSliceCompiled(s Selector) ([]time.Duration, error) {
+	return pickCompiled(p, s, p.Converter.AsDurationSlice)
+}
+
+// TimeWithConfigCompiled is the version of [Picker.TimeWithConfig] that uses a precompiled selector.
+func (p Picker) TimeWithConfigCompiled(config TimeConvertConfig, s Selector) (time.Time, error) {
+	return pickCompiled(p, s, func(input any) (time.Time, error) {
+		return p.Converter.AsTimeWithConfig(config, input)
+	})
+}
+
+// TimeSliceWithConfigCompiled is the version of [Picker.TimeSliceWithConfig] that uses a precompiled selector.
+func (p Picker) TimeSliceWithConfigCompiled(config TimeConvertConfig, s Selector) ([]time.Time, error) {
+	return pickCompiled(p, s, func(input any) ([]time.Time, error) {
+		return p.Converter.AsTimeSliceWithConfig(config, input)
+	})
+}
+
+// DurationWithConfigCompiled is the version of [Picker.DurationWithConfig] that uses a precompiled selector.
+func (p Picker) DurationWithConfigCompiled(config DurationConvertConfig, s Selector) (time.Duration, error) {
+	return pickCompiled(p, s, func(input any) (time.Duration, error) {
+		return p.Converter.AsDurationWithConfig(config, input)
+	})
+}
+
+// DurationSliceWithConfigCompiled is the version of [Picker.DurationSliceWithConfig] that uses a precompiled selector.
+func (p Picker) DurationSliceWithConfigCompiled(config DurationConvertConfig, s Selector) ([]time.Duration, error) {
+	return pickCompiled(p, s, func(input any) ([]time.Duration, error) {
+		return p.Converter.AsDurationSliceWithConfig(config, input)
+	})
+}
+
+// BoolCompiled is the version of [RelaxedAPI.Bool] that uses a precompiled selector.
+func (a RelaxedAPI) BoolCompiled(s Selector) bool {
+	return pickRelaxedCompiled(a, s, a.Converter.AsBool)
+}
+
+// BoolSliceCompiled is the version of [RelaxedAPI.BoolSlice] that uses a precompiled selector.
+func (a RelaxedAPI) BoolSliceCompiled(s Selector) []bool {
+	return pickRelaxedCompiled(a, s, a.Converter.AsBoolSlice)
+}
+
+// ByteCompiled is the version of [RelaxedAPI.Byte] that uses a precompiled selector.
+func (a RelaxedAPI) ByteCompiled(s Selector) byte {
+	return pickRelaxedCompiled(a, s, a.Converter.AsByte)
+}
+
+// ByteSliceCompiled is the version of [RelaxedAPI.ByteSlice] that uses a precompiled selector.
+func (a RelaxedAPI) ByteSliceCompiled(s Selector) []byte {
+	return pickRelaxedCompiled(a, s, a.Converter.AsByteSlice)
+}
+
+// Float32Compiled is the version of [RelaxedAPI.Float32] that uses a precompiled selector.
+func (a RelaxedAPI) Float32Compiled(s Selector) float32 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsFloat32)
+}
+
+// Float32SliceCompiled is the version of [RelaxedAPI.Float32Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Float32SliceCompiled(s Selector) []float32 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsFloat32Slice)
+}
+
+// Float64Compiled is the version of [RelaxedAPI.Float64] that uses a precompiled selector.
+func (a RelaxedAPI) Float64Compiled(s Selector) float64 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsFloat64)
+}
+
+// Float64SliceCompiled is the version of [RelaxedAPI.Float64Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Float64SliceCompiled(s Selector) []float64 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsFloat64Slice)
+}
+
+// IntCompiled is the version of [RelaxedAPI.Int] that uses a precompiled selector.
+func (a RelaxedAPI) IntCompiled(s Selector) int {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt)
+}
+
+// IntSliceCompiled is the version of [RelaxedAPI.IntSlice] that uses a precompiled selector.
+func (a RelaxedAPI) IntSliceCompiled(s Selector) []int {
+	return pickRelaxedCompiled(a, s, a.Converter.AsIntSlice)
+}
+
+// Int8Compiled is the version of [RelaxedAPI.Int8] that uses a precompiled selector.
+func (a RelaxedAPI) Int8Compiled(s Selector) int8 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt8)
+}
+
+// Int8SliceCompiled is the version of [RelaxedAPI.Int8Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Int8SliceCompiled(s Selector) []int8 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt8Slice)
+}
+
+// Int16Compiled is the version of [RelaxedAPI.Int16] that uses a precompiled selector.
+func (a RelaxedAPI) Int16Compiled(s Selector) int16 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt16)
+}
+
+// Int16SliceCompiled is the version of [RelaxedAPI.Int16Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Int16SliceCompiled(s Selector) []int16 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt16Slice)
+}
+
+// Int32Compiled is the version of [RelaxedAPI.Int32] that uses a precompiled selector.
+func (a RelaxedAPI) Int32Compiled(s Selector) int32 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt32)
+}
+
+// Int32SliceCompiled is the version of [RelaxedAPI.Int32Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Int32SliceCompiled(s Selector) []int32 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt32Slice)
+}
+
+// Int64Compiled is the version of [RelaxedAPI.Int64] that uses a precompiled selector.
+func (a RelaxedAPI) Int64Compiled(s Selector) int64 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt64)
+}
+
+// Int64SliceCompiled is the version of [RelaxedAPI.Int64Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Int64SliceCompiled(s Selector) []int64 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsInt64Slice)
+}
+
+// UintCompiled is the version of [RelaxedAPI.Uint] that uses a precompiled selector.
+func (a RelaxedAPI) UintCompiled(s Selector) uint {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint)
+}
+
+// UintSliceCompiled is the version of [RelaxedAPI.UintSlice] that uses a precompiled selector.
+func (a RelaxedAPI) UintSliceCompiled(s Selector) []uint {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUintSlice)
+}
+
+// Uint8Compiled is the version of [RelaxedAPI.Uint8] that uses a precompiled selector.
+func (a RelaxedAPI) Uint8Compiled(s Selector) uint8 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint8)
+}
+
+// Uint8SliceCompiled is the version of [RelaxedAPI.Uint8Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Uint8SliceCompiled(s Selector) []uint8 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint8Slice)
+}
+
+// Uint16Compiled is the version of [RelaxedAPI.Uint16] that uses a precompiled selector.
+func (a RelaxedAPI) Uint16Compiled(s Selector) uint16 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint16)
+}
+
+// Uint16SliceCompiled is the version of [RelaxedAPI.Uint16Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Uint16SliceCompiled(s Selector) []uint16 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint16Slice)
+}
+
+// Uint32Compiled is the version of [RelaxedAPI.Uint32] that uses a precompiled selector.
+func (a RelaxedAPI) Uint32Compiled(s Selector) uint32 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint32)
+}
+
+// Uint32SliceCompiled is the version of [RelaxedAPI.Uint32Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Uint32SliceCompiled(s Selector) []uint32 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint32Slice)
+}
+
+// Uint64Compiled is the version of [RelaxedAPI.Uint64] that uses a precompiled selector.
+func (a RelaxedAPI) Uint64Compiled(s Selector) uint64 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint64)
+}
+
+// Uint64SliceCompiled is the version of [RelaxedAPI.Uint64Slice] that uses a precompiled selector.
+func (a RelaxedAPI) Uint64SliceCompiled(s Selector) []uint64 {
+	return pickRelaxedCompiled(a, s, a.Converter.AsUint64Slice)
+}
+
+// StringCompiled is the version of [RelaxedAPI.String] that uses a precompiled selector.
+func (a RelaxedAPI) StringCompiled(s Selector) string {
+	return pickRelaxedCompiled(a, s, a.Converter.AsString)
+}
+
+// StringSliceCompiled is the version of [RelaxedAPI.StringSlice] that uses a precompiled selector.
+func (a RelaxedAPI) StringSliceCompiled(s Selector) []string {
+	return pickRelaxedCompiled(a, s, a.Converter.AsStringSlice)
+}
+
+// TimeCompiled is the version of [RelaxedAPI.Time] that uses a precompiled selector.
+func (a RelaxedAPI) TimeCompiled(s Selector) time.Time {
+	return pickRelaxedCompiled(a, s, a.Converter.AsTime)
+}
+
+// TimeSliceCompiled is the version of [RelaxedAPI.TimeSlice] that uses a precompiled selector.
+func (a RelaxedAPI) TimeSliceCompiled(s Selector) []time.Time {
+	return pickRelaxedCompiled(a, s, a.Converter.AsTimeSlice)
+}
+
+// DurationCompiled is the version of [RelaxedAPI.Duration] that uses a precompiled selector.
+func (a RelaxedAPI) DurationCompiled(s Selector) time.Duration {
+	return pickRelaxedCompiled(a, s, a.Converter.AsDuration)
+}
+
+// DurationSliceCompiled is the version of [RelaxedAPI.DurationSlice] that uses a precompiled selector.
+func (a RelaxedAPI) DurationSliceCompiled(s Selector) []time.Duration {
+	return pickRelaxedCompiled(a, s, a.Converter.AsDurationSlice)
+}
+
+// TimeWithConfigCompiled is the version of [RelaxedAPI.TimeWithConfig] that uses a precompiled selector.
+func (a RelaxedAPI) TimeWithConfigCompiled(config TimeConvertConfig, s Selector) time.Time {
+	return pickRelaxedCompiled(a, s, func(input any) (time.Time, error) {
+		return a.Converter.AsTimeWithConfig(config, input)
+	})
+}
+
+// TimeSliceWithConfigCompiled is the version of [RelaxedAPI.TimeSliceWithConfig] that uses a precompiled selector.
+func (a RelaxedAPI) TimeSliceWithConfigCompiled(config TimeConvertConfig, s Selector) []time.Time {
+	return pickRelaxedCompiled(a, s, func(input any) ([]time.Time, error) {
+		return a.Converter.AsTimeSliceWithConfig(config, input)
+	})
+}
+
+// DurationWithConfigCompiled is the version of [RelaxedAPI.DurationWithConfig] that uses a precompiled selector.
+func (a RelaxedAPI) DurationWithConfigCompiled(config DurationConvertConfig, s Selector) time.Duration {
+	return pickRelaxedCompiled(a, s, func(input any) (time.Duration, error) {
+		return a.Converter.AsDurationWithConfig(config, input)
+	})
+}
+
+// DurationSliceWithConfigCompiled is the version of [RelaxedAPI.DurationSliceWithConfig] that uses a precompiled selector.
+func (a RelaxedAPI) DurationSliceWithConfigCompiled(config DurationConvertConfig, s Selector) []time.Duration {
+	return pickRelaxedCompiled(a, s, func(input any) ([]time.Duration, error) {
+		return a.Converter.AsDurationSliceWithConfig(config, input)
+	})
+}
@@ -0,0 +1,72 @@
+package pick
+
+import (
+	"reflect"
+	"slices"
+	"sync/atomic"
+
+	"github.com/moukoublen/pick/internal/lru"
+)
+
+// SelectorCache is a bounded, concurrency safe, least recently used (LRU) cache of parsed selectors (`selector -> []Key`).
+// It can be attached to a Picker using the WithSelectorCache option and it is shared by all the pickers produced by `Wrap`.
+// The selectors are cached per notation type, so the same cache can be shared by pickers that use different notations.
+type SelectorCache struct {
+	paths  *lru.Cache[selectorCacheKey, []Key]
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// selectorCacheKey identifies a selector of a notation (the same selector may mean a different path in another notation).
+type selectorCacheKey struct {
+	notation reflect.Type
+	selector string
+}
+
+// SelectorCacheStats is a snapshot of the cache counters.
+type SelectorCacheStats struct {
+	Hits     uint64
+	Misses   uint64
+	Size     int
+	Capacity int
+}
+
+// NewSelectorCache creates a cache that holds up to capacity parsed selectors. A capacity less than 1 is treated as 1.
+func NewSelectorCache(capacity int) *SelectorCache {
+	return &SelectorCache{paths: lru.New[selectorCacheKey, []Key](capacity)}
+}
+
+// parse returns the cached path of the selector or parses it using the notation and caches it.
+// Selectors that fail to parse are not cached. The returned path is shared and must not be modified.
+func (c *SelectorCache) parse(n Notation, selector string) ([]Key, error) {
+	key := selectorCacheKey{notation: reflect.TypeOf(n), selector: selector}
+
+	if path, found := c.paths.Get(key); found {
+		c.hits.Add(1)
+		return path, nil
+	}
+	c.misses.Add(1)
+
+	path, err := n.Parse(selector)
+	if err != nil {
+		return path, err
+	}
+
+	// clip, so that appending to the shared path never writes to its backing array.
+	return c.paths.Add(key, slices.Clip(path)), nil
+}
+
+// Stats returns the hit/miss counters, the current size and the capacity of the cache.
+func (c *SelectorCache) Stats() SelectorCacheStats {
+	return SelectorCacheStats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Size:     c.paths.Len(),
+		Capacity: c.paths.Capacity(),
+	}
+}
+
+// Purge removes all the cached selectors. The counters are not reset.
+func (c *SelectorCache) Purge() {
+	c.paths.Purge()
+}
@@ -0,0 +1,150 @@
+package pick
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorCache(t *testing.T) {
+	c := NewSelectorCache(2)
+	n := DotNotation{}
+
+	path, err := c.parse(n, "a.b")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, path, []Key{Field("a"), Field("b")})
+	testingx.AssertEqual(t, cap(path), len(path))
+
+	_, _ = c.parse(n, "a.b")
+	_, _ = c.parse(n, "c[0]")
+	testingx.AssertEqual(t, c.Stats(), SelectorCacheStats{Hits: 1, Misses: 2, Size: 2, Capacity: 2})
+
+	// "a.b" is the most recently used, so "c[0]" is evicted.
+	_, _ = c.parse(n, "a.b")
+	_, _ = c.parse(n, "d")
+	_, _ = c.parse(n, "c[0]")
+	testingx.AssertEqual(t, c.Stats(), SelectorCacheStats{Hits: 2, Misses: 4, Size: 2, Capacity: 2})
+	_, _ = c.parse(n, "d")
+	testingx.AssertEqual(t, c.Stats().Hits, uint64(3))
+
+	// errors are not cached.
+	_, err = c.parse(n, "a[")
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+	_, err = c.parse(n, "a[")
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+	testingx.AssertEqual(t, c.Stats(), SelectorCacheStats{Hits: 3, Misses: 6, Size: 2, Capacity: 2})
+
+	c.Purge()
+	testingx.AssertEqual(t, c.Stats(), SelectorCacheStats{Hits: 3, Misses: 6, Size: 0, Capacity: 2})
+
+	testingx.AssertEqual(t, NewSelectorCache(0).Stats().Capacity, 1)
+}
+
+func TestSelectorCacheConcurrent(t *testing.T) {
+	c := NewSelectorCache(8)
+	p := NewPicker(map[string]any{"a": []any{1, 2, 3}}, NewDefaultTraverser(NewDefaultConverter()), NewDefaultConverter(), DotNotation{}, WithSelectorCache(c))
+
+	wg := sync.WaitGroup{}
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				selector := "a[" + strconv.Itoa((g+i)%3) + "]"
+				got, err := p.Int(selector)
+				if err != nil || got != (g+i)%3+1 {
+					t.Errorf("unexpected result %d, %v for %s", got, err, selector)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	testingx.AssertEqual(t, stats.Hits+stats.Misses, uint64(800))
+	testingx.AssertEqual(t, stats.Size, 3)
+}
+
+func TestPickerWithSelectorCache(t *testing.T) {
+	c := NewSelectorCache(16)
+	converter := NewDefaultConverter()
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"id": 1},
+			map[string]any{"id": "x"},
+		},
+	}
+	p := NewPicker(data, NewDefaultTraverser(converter), converter, DotNotation{}, WithSelectorCache(c))
+	require.Same(t, c, p.SelectorCache())
+	require.Nil(t, Wrap(data).SelectorCache())
+
+	// pickers produced by Wrap share the cache.
+	err := Each(p, "items", func(_ int, item Picker, _ int) error {
+		require.Same(t, c, item.SelectorCache())
+		_, _ = item.Any("id")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Same(t, c, p.Relaxed().Wrap(nil).SelectorCache())
+
+	// the cached path is not modified by the functions that append to the path (e.g. for error reporting).
+	sink := &ErrorsSink{}
+	ids := RelaxedMap(p.Relaxed(sink), "items", func(a RelaxedAPI) (int, error) {
+		return Get[int](a.Picker, "id")
+	})
+	testingx.AssertEqual(t, ids, []int{1, 0})
+	tst.ErrorIs(ErrConvertInvalidSyntax)(t, sink.Outcome())
+
+	path, err := c.parse(DotNotation{}, "items")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, path, []Key{Field("items")})
+
+	stats := c.Stats()
+	testingx.AssertEqual(t, stats.Size, 2)
+	testingx.AssertEqual(t, stats.Misses, uint64(2))
+	testingx.AssertEqual(t, stats.Hits, uint64(5))
+}
+
+func TestSelectorCacheNotations(t *testing.T) {
+	c := NewSelectorCache(16)
+	converter := NewDefaultConverter()
+	data := map[string]any{"s": 1, "$": map[string]any{"s": 2}}
+
+	jsonPath := NewPicker(data, NewDefaultTraverser(converter), converter, JSONPathNotation{}, WithSelectorCache(c))
+	dot := NewPicker(data, NewDefaultTraverser(converter), converter, DotNotation{}, WithSelectorCache(c))
+
+	// the same selector is a different path in each notation.
+	v, err := jsonPath.Int("$.s")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, v, 1)
+	v, err = dot.Int("$.s")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, v, 2)
+
+	testingx.AssertEqual(t, c.Stats().Size, 2)
+}
+
+func BenchmarkSelectorCache(b *testing.B) {
+	const selector = "near_earth_objects.2023-01-01[5].estimated_diameter.meters.estimated_diameter_max"
+	converter := NewDefaultConverter()
+	plain := Wrap(nil)
+	cached := NewPicker(nil, NewDefaultTraverser(converter), converter, DotNotation{}, WithSelectorCache(NewSelectorCache(64)))
+
+	b.Run("not cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = plain.parse(selector)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = cached.parse(selector)
+		}
+	})
+}
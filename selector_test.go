@@ -0,0 +1,152 @@
+package pick
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	s, err := Compile("a.b[2].c")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, s.String(), "a.b[2].c")
+	testingx.AssertEqual(t, s.Path(), []Key{Field("a"), Field("b"), Index(2), Field("c")})
+
+	// the returned path is a copy.
+	s.Path()[0] = Field("x")
+	testingx.AssertEqual(t, s.Path()[0], Field("a"))
+
+	_, err = Compile("a[")
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+
+	require.Panics(t, func() { MustCompile("a[") })
+	require.NotPanics(t, func() { MustCompile("a[0]") })
+
+	s, err = CompileWithNotation(JSONPointerNotation{}, "/a/0")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, s.Path(), []Key{Field("a"), Index(0)})
+
+	c := NewDefaultConverter()
+	p := NewPicker(nil, NewDefaultTraverser(c), c, JSONPathNotation{})
+	s, err = p.Compile("$.a['b.c']")
+	tst.NoError()(t, err)
+	testingx.AssertEqual(t, s.Path(), []Key{Field("a"), Field("b.c")})
+}
+
+func TestCompiledSelectorPicker(t *testing.T) {
+	p, err := WrapJSON([]byte(`{"a": {"b": [1, 2, {"c": "3"}]}}`))
+	require.NoError(t, err)
+
+	s := MustCompile("a.b[2].c")
+	missing := MustCompile("a.b[5].c")
+
+	got, err := p.AnyCompiled(s)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, got, any("3"))
+
+	n, err := GetCompiled[int](p, s)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, n, 3)
+
+	_, err = GetCompiled[int](p, missing)
+	tst.ErrorIs(ErrIndexOutOfRange)(t, err)
+	testingx.AssertEqual(t, err.Error(), "selector: a.b[5] : error trying to traverse: field not found: index out of range")
+
+	n, err = OrDefaultCompiled(p, missing, 7)
+	require.NoError(t, err)
+	testingx.AssertEqual(t, n, 7)
+
+	sink := &ErrorsSink{}
+	r := p.Relaxed(sink)
+	testingx.AssertEqual(t, RelaxedGetCompiled[string](r, s), "3")
+	testingx.AssertEqual(t, RelaxedOrDefaultCompiled(r, missing, "default"), "default")
+	testingx.AssertEqual(t, r.AnyCompiled(s), any("3"))
+	require.NoError(t, sink.Outcome())
+
+	testingx.AssertEqual(t, RelaxedGetCompiled[int](r, missing), 0)
+	testingx.AssertEqual(t, r.AnyCompiled(missing), nil)
+
+	var pe *PickerError
+	require.True(t, errors.As(sink.Outcome(), &pe))
+	testingx.AssertEqual(t, pe.Selector(), "a.b[5].c")
+	tst.ErrorIs(ErrIndexOutOfRange)(t, sink.Outcome())
+}
+
+func TestCompiledSelectorErrorPathIsNotShared(t *testing.T) {
+	p := Wrap(map[string]any{"a": map[string]any{"b": 1}})
+	s := MustCompile("a.missing.c")
+
+	_, err := p.AnyCompiled(s)
+	var te *TraverseError
+	require.True(t, errors.As(err, &te))
+	path := te.Path()
+	testingx.AssertEqual(t, path, []Key{Field("a"), Field("missing")})
+
+	// appending to the error path must not modify the path of the selector.
+	_ = append(path, Field("x"))
+	path[0] = Field("changed")
+	testingx.AssertEqual(t, s.Path(), []Key{Field("a"), Field("missing"), Field("c")})
+
+	_, err = p.AnyCompiled(s)
+	tst.ErrorIs(ErrFieldNotFound)(t, err)
+	testingx.AssertEqual(t, err.Error(), "selector: a.missing : error trying to traverse: field not found")
+}
+
+func TestCompiledSelectorTypedAccessors(t *testing.T) {
+	p := Wrap(map[string]any{"n": "12", "ns": []any{1, "2"}, "d": "1s", "t": "2024-01-02T03:04:05Z", "flag": "true"})
+
+	n, err := p.IntCompiled(MustCompile("n"))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, n, 12)
+
+	ns, err := p.Int64SliceCompiled(MustCompile("ns"))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, ns, []int64{1, 2})
+
+	d, err := p.DurationCompiled(MustCompile("d"))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, d, time.Second)
+
+	tm, err := p.TimeWithConfigCompiled(TimeConvertConfig{StringFormat: time.RFC3339}, MustCompile("t"))
+	require.NoError(t, err)
+	testingx.AssertEqual(t, tm, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	_, err = p.StringCompiled(MustCompile("missing"))
+	tst.ErrorIs(ErrFieldNotFound)(t, err)
+
+	sink := &ErrorsSink{}
+	r := p.Relaxed(sink)
+	testingx.AssertEqual(t, r.BoolCompiled(MustCompile("flag")), true)
+	testingx.AssertEqual(t, r.StringSliceCompiled(MustCompile("ns")), []string{"1", "2"})
+	testingx.AssertEqual(t, r.Float64Compiled(MustCompile("n")), 12.0)
+	require.NoError(t, sink.Outcome())
+
+	testingx.AssertEqual(t, r.Uint8Compiled(MustCompile("missing")), uint8(0))
+	var pe *PickerError
+	require.True(t, errors.As(sink.Outcome(), &pe))
+	testingx.AssertEqual(t, pe.Selector(), "missing")
+}
+
+func BenchmarkCompiledSelector(b *testing.B) {
+	p, _ := WrapJSON([]byte(`{"a": {"b": [1, 2, {"c": "3"}]}}`))
+	const selector = "a.b[2].c"
+	s := MustCompile(selector)
+
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = p.Any(selector)
+		}
+	})
+
+	b.Run("compiled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = p.AnyCompiled(s)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package pick
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// WrapReaderJSONSelect reads a JSON document from the reader in a streaming way, and wraps only the values that are selected
+// by the selectors (parsed using the dot notation) into a Picker. See WrapDecoderSelect.
+func WrapReaderJSONSelect(r io.Reader, selectors ...string) (Picker, error) {
+	compiled := make([]Selector, 0, len(selectors))
+	for _, s := range selectors {
+		c, err := Compile(s)
+		if err != nil {
+			return Picker{}, err
+		}
+		compiled = append(compiled, c)
+	}
+
+	return WrapDecoderSelect(json.NewDecoder(r), compiled...)
+}
+
+// WrapDecoderSelect scans the next JSON document of the decoder once, token by token, and decodes only the subtrees that are
+// selected by the selectors, while every other value is skipped without being decoded. This way only a few values of a large
+// document can be picked without holding the whole decoded document in memory.
+//
+// The returned Picker wraps a partial document that has the shape of the original one, so the same selectors can be used on it:
+// objects hold only the selected members and arrays hold the selected elements in their original indices (the rest are nil),
+// while the members and the trailing elements that do not have the rest of the selected keys are omitted.
+// Only field, (non negative) index, wildcard, slice (with non negative bounds and step) and union keys are supported,
+// since the rest (e.g. negative indices, recursive descent and filters) require the whole document.
+func WrapDecoderSelect(d *json.Decoder, selectors ...Selector) (Picker, error) {
+	paths := make([][]Key, 0, len(selectors))
+	for _, s := range selectors {
+		for i, k := range s.path {
+			if !streamKeySupported(k) {
+				return Picker{}, NewTraverseError("error trying to stream", s.path, i, ErrStreamKeyNotSupported)
+			}
+		}
+		paths = append(paths, s.path)
+	}
+
+	s := streamScanner{decoder: d}
+	v, _, err := s.value(paths)
+	if err != nil {
+		return Picker{}, err
+	}
+
+	return Wrap(v), nil
+}
+
+// streamScanner materializes the selected values of a JSON document using the tokens of a decoder.
+type streamScanner struct {
+	decoder *json.Decoder
+}
+
+// value scans the next value. The paths are the remaining keys of the selectors that reach the value.
+// It returns false if the value is not selected (and it is skipped).
+func (s streamScanner) value(paths [][]Key) (any, bool, error) {
+	if len(paths) == 0 {
+		return nil, false, s.skip()
+	}
+
+	for _, p := range paths {
+		if len(p) == 0 { // the whole value is selected.
+			var v any
+			if err := s.decoder.Decode(&v); err != nil {
+				return nil, false, err
+			}
+			return v, true, nil
+		}
+	}
+
+	t, err := s.decoder.Token()
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		return s.object(paths)
+	case json.Delim('['):
+		return s.array(paths)
+	default:
+		// a scalar does not have the keys of the paths.
+		return nil, false, nil
+	}
+}
+
+func (s streamScanner) object(paths [][]Key) (any, bool, error) {
+	m := map[string]any{}
+	for s.decoder.More() {
+		t, err := s.decoder.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		name, _ := t.(string)
+
+		v, found, err := s.value(nextStreamPaths(paths, func(k Key) bool { return streamKeyMatchesField(k, name) }))
+		if err != nil {
+			return nil, false, err
+		}
+		if found {
+			m[name] = v
+		}
+	}
+
+	// closing delimiter.
+	if _, err := s.decoder.Token(); err != nil {
+		return nil, false, err
+	}
+
+	return m, true, nil
+}
+
+func (s streamScanner) array(paths [][]Key) (any, bool, error) {
+	sl := []any{}
+	for i := 0; s.decoder.More(); i++ {
+		v, found, err := s.value(nextStreamPaths(paths, func(k Key) bool { return streamKeyMatchesIndex(k, i) }))
+		if err != nil {
+			return nil, false, err
+		}
+		if found {
+			// the selected elements keep their index, the ones that are not selected (or do not have the selected keys) are nil.
+			for len(sl) < i {
+				sl = append(sl, nil)
+			}
+			sl = append(sl, v)
+		}
+	}
+
+	// closing delimiter.
+	if _, err := s.decoder.Token(); err != nil {
+		return nil, false, err
+	}
+
+	return sl, true, nil
+}
+
+// skip consumes the next value without decoding it.
+func (s streamScanner) skip() error {
+	depth := 0
+	for {
+		t, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// nextStreamPaths returns the rest of the paths whose first key matches.
+func nextStreamPaths(paths [][]Key, matches func(k Key) bool) [][]Key {
+	var next [][]Key
+	for _, p := range paths {
+		if matches(p[0]) {
+			next = append(next, p[1:])
+		}
+	}
+
+	return next
+}
+
+func streamKeySupported(k Key) bool {
+	switch k.Type {
+	case KeyTypeField, KeyTypeWildcard:
+		return true
+	case KeyTypeIndex:
+		return k.Index >= 0
+	case KeyTypeSlice:
+		b := k.Slice
+		return b == nil || (b.Step >= 0 && (b.Start == nil || *b.Start >= 0) && (b.End == nil || *b.End >= 0))
+	case KeyTypeUnion:
+		for _, m := range k.UnionKeys() {
+			if !streamKeySupported(m) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func streamKeyMatchesField(k Key, name string) bool {
+	switch k.Type {
+	case KeyTypeField:
+		return k.Name == name
+	case KeyTypeIndex:
+		// like the traverser, an index key addresses the object member that is named after the index.
+		return strconv.Itoa(k.Index) == name
+	case KeyTypeWildcard:
+		return true
+	case KeyTypeUnion:
+		for _, m := range k.UnionKeys() {
+			if streamKeyMatchesField(m, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func streamKeyMatchesIndex(k Key, i int) bool {
+	switch k.Type {
+	case KeyTypeIndex:
+		return k.Index == i
+	case KeyTypeField:
+		// like the traverser, a field key addresses an array element only by its canonical index (e.g. `1`, but not `01`).
+		return strconv.Itoa(i) == k.Name
+	case KeyTypeWildcard:
+		return true
+	case KeyTypeSlice:
+		if k.Slice == nil {
+			return true
+		}
+		start, step := 0, max(k.Slice.Step, 1)
+		if k.Slice.Start != nil {
+			start = *k.Slice.Start
+		}
+		return i >= start && (k.Slice.End == nil || i < *k.Slice.End) && (i-start)%step == 0
+	case KeyTypeUnion:
+		for _, m := range k.UnionKeys() {
+			if streamKeyMatchesIndex(m, i) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var ErrStreamKeyNotSupported = errors.New("key is not supported in streaming mode")
@@ -0,0 +1,220 @@
+package pick
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+const streamTestDocument = `{
+	"meta": {"id": "abc", "count": 3, "tags": ["a", "b"]},
+	"items": [
+		{"id": 1, "name": "one", "details": {"weight": 1.5}},
+		{"id": 2, "name": "two", "details": {"weight": 2.5}},
+		{"id": 3, "name": "three", "details": null}
+	],
+	"large": {"nested": [[1, 2], {"x": [3, {"y": 4}]}], "text": "skipped"},
+	"flag": true
+}`
+
+func TestWrapReaderJSONSelect(t *testing.T) {
+	tests := map[string]struct {
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		selectors     []string
+	}{
+		"fields": {
+			selectors:     []string{"meta.id", "flag"},
+			expected:      map[string]any{"meta": map[string]any{"id": "abc"}, "flag": true},
+			errorAsserter: tst.NoError(),
+		},
+		"whole subtree": {
+			selectors:     []string{"meta"},
+			expected:      map[string]any{"meta": map[string]any{"id": "abc", "count": float64(3), "tags": []any{"a", "b"}}},
+			errorAsserter: tst.NoError(),
+		},
+		"index keeps position": {
+			selectors:     []string{"items[1].name"},
+			expected:      map[string]any{"items": []any{nil, map[string]any{"name": "two"}}},
+			errorAsserter: tst.NoError(),
+		},
+		"wildcard": {
+			selectors: []string{"items[*].id"},
+			expected: map[string]any{"items": []any{
+				map[string]any{"id": float64(1)},
+				map[string]any{"id": float64(2)},
+				map[string]any{"id": float64(3)},
+			}},
+			errorAsserter: tst.NoError(),
+		},
+		"slice": {
+			selectors:     []string{"items[1:].details.weight"},
+			expected:      map[string]any{"items": []any{nil, map[string]any{"details": map[string]any{"weight": 2.5}}, map[string]any{}}},
+			errorAsserter: tst.NoError(),
+		},
+		"union": {
+			selectors:     []string{"meta['id','count']"},
+			expected:      map[string]any{"meta": map[string]any{"id": "abc", "count": float64(3)}},
+			errorAsserter: tst.NoError(),
+		},
+		"overlapping selectors": {
+			selectors:     []string{"meta.tags[0]", "meta.tags"},
+			expected:      map[string]any{"meta": map[string]any{"tags": []any{"a", "b"}}},
+			errorAsserter: tst.NoError(),
+		},
+		"missing": {
+			selectors:     []string{"meta.missing", "flag.x"},
+			expected:      map[string]any{"meta": map[string]any{}},
+			errorAsserter: tst.NoError(),
+		},
+		"no selectors": {
+			selectors:     nil,
+			expected:      nil,
+			errorAsserter: tst.NoError(),
+		},
+		"whole document": {
+			selectors:     []string{""},
+			expected:      mustDecodeJSON(t, streamTestDocument),
+			errorAsserter: tst.NoError(),
+		},
+		"negative index": {
+			selectors:     []string{"items[-1].id"},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrStreamKeyNotSupported),
+		},
+		"recursive descent": {
+			selectors:     []string{"..id"},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrStreamKeyNotSupported),
+		},
+		"invalid selector": {
+			selectors:     []string{"items[1"},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrInvalidSelectorFormatForIndex),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := WrapReaderJSONSelect(strings.NewReader(streamTestDocument), tc.selectors...)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, p.Data(), tc.expected)
+		})
+	}
+}
+
+func TestWrapReaderJSONSelectMatchesFullDocument(t *testing.T) {
+	selectors := []string{"meta.id", "meta.tags[1]", "items[*].id", "items[2].name", "items[0:2].details.weight", "large.nested[1].x[1].y", "flag"}
+
+	full, err := WrapJSON([]byte(streamTestDocument))
+	require.NoError(t, err)
+	streamed, err := WrapReaderJSONSelect(strings.NewReader(streamTestDocument), selectors...)
+	require.NoError(t, err)
+
+	for _, s := range selectors {
+		expected, err := full.Any(s)
+		require.NoError(t, err)
+		got, err := streamed.Any(s)
+		require.NoError(t, err)
+		testingx.AssertEqual(t, got, expected)
+	}
+
+	// the values that are not selected are not decoded.
+	_, err = streamed.Any("large.text")
+	tst.ErrorIs(ErrFieldNotFound)(t, err)
+}
+
+func TestWrapDecoderSelectParity(t *testing.T) {
+	const document = `{
+		"a": {"0": "zero", "1": {"x": 1}},
+		"list": [1, {"x": 2}, "s", {"y": 3}, {"x": 4}],
+		"nested": [[1, 2], [3, {"x": 5}]]
+	}`
+
+	selectors := []Selector{
+		MustCompile("a[0]"),
+		MustCompile("a[1].x"),
+		MustCompile("list[*].x"),
+		MustCompile("list[1:4].x"),
+		MustCompile("list[0,2].x"),
+		MustCompile("list[3]"),
+		MustCompile("nested[*][1].x"),
+		MustCompile("a.missing"),
+		MustCompile("list.1.x"),
+		MustCompile("nested.1.1.x"),
+	}
+	for _, pointer := range []string{"/a/0", "/a/1/x", "/list/4/x", "/nested/1/1", "/list/01"} {
+		s, err := CompileWithNotation(JSONPointerNotation{}, pointer)
+		require.NoError(t, err)
+		selectors = append(selectors, s)
+	}
+
+	full, err := WrapJSON([]byte(document))
+	require.NoError(t, err)
+	for _, s := range selectors {
+		t.Run(s.String(), func(t *testing.T) {
+			streamed, err := WrapDecoderSelect(json.NewDecoder(strings.NewReader(document)), s)
+			require.NoError(t, err)
+
+			expected, expectedErr := full.Path(s.Path())
+			got, err := streamed.Path(s.Path())
+			testingx.AssertEqual(t, err != nil, expectedErr != nil)
+			testingx.AssertEqual(t, got, expected)
+		})
+	}
+
+	t.Run("index as object member", func(t *testing.T) {
+		s, err := CompileWithNotation(JSONPointerNotation{}, "/a/0")
+		require.NoError(t, err)
+		streamed, err := WrapDecoderSelect(json.NewDecoder(strings.NewReader(document)), s)
+		require.NoError(t, err)
+		got, err := streamed.Path(s.Path())
+		require.NoError(t, err)
+		testingx.AssertEqual(t, got, any("zero"))
+	})
+
+	t.Run("elements without the keys are omitted", func(t *testing.T) {
+		streamed, err := WrapReaderJSONSelect(strings.NewReader(document), "list[0:3].x")
+		require.NoError(t, err)
+		testingx.AssertEqual(t, streamed.Data(), any(map[string]any{
+			"list": []any{nil, map[string]any{"x": float64(2)}},
+		}))
+	})
+}
+
+func TestWrapDecoderSelect(t *testing.T) {
+	d := json.NewDecoder(strings.NewReader(streamTestDocument))
+	d.UseNumber()
+
+	p, err := WrapDecoderSelect(d, MustCompile("meta.count"), MustCompile("items[0].details"))
+	require.NoError(t, err)
+
+	count, err := p.Any("meta.count")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, count, json.Number("3"))
+
+	weight, err := p.Float64("items[0].details.weight")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, weight, 1.5)
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := WrapReaderJSONSelect(strings.NewReader(`{"a": [1, 2`), "a[0]")
+		tst.Error()(t, err)
+
+		_, err = WrapReaderJSONSelect(strings.NewReader(`{"a": {"b": }`), "c")
+		tst.Error()(t, err)
+	})
+}
+
+func mustDecodeJSON(t *testing.T, js string) any {
+	t.Helper()
+
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(js), &v))
+
+	return v
+}
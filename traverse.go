@@ -1,12 +1,19 @@
 package pick
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/moukoublen/pick/internal/errorsx"
+	"github.com/moukoublen/pick/internal/lru"
+	"github.com/moukoublen/pick/iter"
 )
 
 type KeyConverter interface {
@@ -16,16 +23,39 @@ type KeyConverter interface {
 
 type DefaultTraverser struct {
 	keyConverter        KeyConverter
+	structFields        *structFieldCache
 	nilVal              reflect.Value
+	fieldMatching       FieldMatching
 	skipItemDereference bool
+	methodGetters       bool
+	embeddedJSON        *lru.Cache[string, any] // the decoded embedded JSON documents by content (see WithEmbeddedJSON).
+	getterExclusions    []reflect.Type          // the types whose methods are never called as getters (see WithMethodGetters).
 }
 
-func NewDefaultTraverser(keyConverter KeyConverter) DefaultTraverser {
-	return DefaultTraverser{
+// DefaultTraverserOption configures optional behavior of a DefaultTraverser.
+type DefaultTraverserOption func(d *DefaultTraverser)
+
+// WithStructTags sets the struct tags (in priority order) that are used to resolve the names of struct fields. The default is `json`, `config`.
+// A field is addressed by the name of the first tag that defines one, or else by its Go name. Fields with a `-` tag (e.g. `json:"-"`) are not addressable.
+func WithStructTags(tags ...string) DefaultTraverserOption {
+	return func(d *DefaultTraverser) {
+		d.structFields = newStructFieldCache(tags)
+	}
+}
+
+func NewDefaultTraverser(keyConverter KeyConverter, opts ...DefaultTraverserOption) DefaultTraverser {
+	d := DefaultTraverser{
 		keyConverter:        keyConverter,
 		skipItemDereference: false,
 		nilVal:              reflect.Value{},
+		structFields:        defaultStructFieldCache,
+	}
+
+	for _, o := range opts {
+		o(&d)
 	}
+
+	return d
 }
 
 func (d DefaultTraverser) Retrieve(data any, path []Key) (any, error) {
@@ -40,31 +70,386 @@ func (d DefaultTraverser) Retrieve(data any, path []Key) (any, error) {
 
 	currentItem = data
 	for i, field := range path {
+		// from the first key that may select multiple elements and onwards, the traversal fans out.
+		if field.selectsMultiple() {
+			matches, err := d.retrieveMatches(currentItem, path, i, false)
+			if err != nil {
+				return nil, err
+			}
+
+			values := make([]any, len(matches))
+			for j := range matches {
+				values[j] = matches[j].Value
+			}
+			return values, nil
+		}
+
 		currentItem, err = d.accessKey(currentItem, field)
 		if err != nil {
 			return currentItem, NewTraverseError("error trying to traverse", path, i, err)
 		}
 	}
 
-	if currentItem == nil || d.skipItemDereference {
-		return currentItem, nil
+	return d.derefResult(currentItem), nil
+}
+
+// Match is a single result of a traversal together with the concrete path (field and index keys only) that leads to it.
+type Match struct {
+	Value any
+	Path  []Key
+}
+
+// RetrieveMatches traverses the path and returns every result together with its concrete path.
+// Keys that select multiple elements (e.g. wildcard, recursive descent) are resolved to the actual field/index keys.
+// Elements that do not contain the rest of the path after a key that selects multiple elements are skipped.
+func (d DefaultTraverser) RetrieveMatches(data any, path []Key) ([]Match, error) {
+	return d.retrieveMatches(data, path, 0, true)
+}
+
+// retrieveMatches traverses the path, starting from the key with index `from`, for each one of the selected elements
+// and returns all the results in order. If trackPaths is false the paths of the matches are not calculated.
+// After the first key that selects multiple elements, the elements that do not have the rest of the path are skipped,
+// while after a recursive descent (which visits every node) the elements whose keys cannot be converted from the key are skipped too.
+func (d DefaultTraverser) retrieveMatches(item any, path []Key, from int, trackPaths bool) ([]Match, error) {
+	current := []Match{{Value: item}}
+	if trackPaths {
+		current[0].Path = slices.Clone(path[:from])
+	}
+
+	fannedOut, descended := false, false
+	for i := from; i < len(path); i++ {
+		key := path[i]
+		descended = descended || key.IsRecursiveDescent()
+
+		var filter filterExpression
+		if key.IsFilter() {
+			var err error
+			filter, err = key.filterExpr()
+			if err != nil {
+				return nil, NewTraverseError("error trying to traverse", path, i, err)
+			}
+		}
+
+		next := make([]Match, 0, len(current))
+		for _, c := range current {
+			if key.selectsMultiple() {
+				fannedOut = true
+				add := func(rel []Key, value any) {
+					m := Match{Value: value}
+					if trackPaths {
+						m.Path = append(slices.Clip(c.Path), rel...)
+					}
+					next = append(next, m)
+				}
+				if filter != nil {
+					d.eachFiltered(c.Value, filter, add)
+				} else {
+					d.eachSelected(c.Value, key, add)
+				}
+				continue
+			}
+
+			v, err := d.accessKey(c.Value, key)
+			if err != nil {
+				// after fan out, elements that do not match the key are skipped.
+				if (fannedOut && errors.Is(err, ErrFieldNotFound)) || (descended && errors.Is(err, ErrKeyConvert)) {
+					continue
+				}
+				return nil, NewTraverseError("error trying to traverse", path, i, err)
+			}
+
+			m := Match{Value: v}
+			if trackPaths {
+				m.Path = append(slices.Clip(c.Path), d.concreteKey(c.Value, key))
+			}
+			next = append(next, m)
+		}
+		current = next
+	}
+
+	for i := range current {
+		current[i].Value = d.derefResult(current[i].Value)
+	}
+
+	return current, nil
+}
+
+// concreteKey resolves negative indices to the actual index of the item.
+func (d DefaultTraverser) concreteKey(item any, key Key) Key {
+	if !key.IsIndex() || key.Index >= 0 {
+		return key
+	}
+
+	l, err := iter.Len(item)
+	if err != nil {
+		return key
+	}
+
+	return Index(l + key.Index)
+}
+
+// fieldIndex converts the name of a field key to an index. Only canonical integers are converted (e.g. `1` or `-1`, but not `01` or `+1`),
+// so that e.g. the JSON Pointer token `01`, which RFC 6901 does not allow as an array index, does not address an element.
+func (d DefaultTraverser) fieldIndex(name string) (int, error) {
+	i, err := d.keyConverter.AsInt(name)
+	if err != nil {
+		return 0, errors.Join(ErrKeyConvert, err)
+	}
+	if strconv.Itoa(i) != name {
+		return 0, fmt.Errorf("%w: %q is not a canonical index", ErrKeyConvert, name)
+	}
+
+	return i, nil
+}
+
+// derefResult dereferences the final result if it is a pointer or interface.
+func (d DefaultTraverser) derefResult(item any) any {
+	if item == nil || d.skipItemDereference {
+		return item
+	}
+
+	// a sync.Map must not be copied.
+	if _, is := item.(*sync.Map); is {
+		return item
 	}
 
 	// try dereference if pointer or interface
-	typeOfItem := reflect.TypeOf(currentItem)
+	typeOfItem := reflect.TypeOf(item)
 	kindOfItem := typeOfItem.Kind()
 	if kindOfItem == reflect.Pointer || kindOfItem == reflect.Interface {
-		return d.deref(currentItem), nil
+		return d.deref(item)
+	}
+
+	return item
+}
+
+// eachSelected calls the fn for each element of the item that is selected by a key that selects multiple elements.
+// The rel is the path of the element relative to the item, it is only valid during the fn call.
+func (d DefaultTraverser) eachSelected(item any, key Key, fn func(rel []Key, value any)) {
+	switch key.Type {
+	case KeyTypeWildcard:
+		var rel [1]Key
+		d.eachChild(item, func(k Key, value any) {
+			rel[0] = k
+			fn(rel[:], value)
+		})
+
+	case KeyTypeRecursiveDescent:
+		d.eachDescendant(item, make([]Key, 0, 8), visiting{}, fn) //nolint:mnd
+
+	case KeyTypeSlice:
+		var rel [1]Key
+		d.eachSliceElement(item, key.Slice, func(k Key, value any) {
+			rel[0] = k
+			fn(rel[:], value)
+		})
+
+	case KeyTypeFilter:
+		// this is the case of union members (see retrieveMatches), the filters are compiled when the keys are created.
+		filter, err := key.filterExpr()
+		if err != nil {
+			return
+		}
+		d.eachFiltered(item, filter, fn)
+
+	case KeyTypeUnion:
+		var rel [1]Key
+		for _, member := range key.UnionKeys() {
+			if member.selectsMultiple() {
+				d.eachSelected(item, member, fn)
+				continue
+			}
+
+			// members that do not exist in the item are skipped.
+			v, err := d.accessKey(item, member)
+			if err != nil {
+				continue
+			}
+			rel[0] = d.concreteKey(item, member)
+			fn(rel[:], v)
+		}
+	}
+}
+
+// eachFiltered calls fn for each child of the item (see eachChild) that matches the filter expression.
+func (d DefaultTraverser) eachFiltered(item any, filter filterExpression, fn func(rel []Key, value any)) {
+	var rel [1]Key
+	d.eachChild(item, func(k Key, value any) {
+		if !filter.match(d, value) {
+			return
+		}
+		rel[0] = k
+		fn(rel[:], value)
+	})
+}
+
+// eachSliceElement calls fn for each element of a slice/array that is selected by the bounds.
+func (d DefaultTraverser) eachSliceElement(item any, bounds *SliceBounds, fn func(k Key, value any)) {
+	if bounds == nil {
+		bounds = &SliceBounds{}
+	}
+
+	// attempts to fast return without reflect.
+	if sl, is := item.([]any); is {
+		for _, i := range bounds.Indices(len(sl)) {
+			fn(Index(i), sl[i])
+		}
+		return
+	}
+
+	if item == nil {
+		return
+	}
+
+	if decoded, is := d.decodeEmbeddedJSON(item); is {
+		d.eachSliceElement(decoded, bounds, fn)
+		return
+	}
+
+	if _, is := item.(iter.Iterable); is || iter.IsSequence(item) {
+		elements := sequenceElements(item)
+		for _, i := range bounds.Indices(len(elements)) {
+			fn(Index(i), elements[i])
+		}
+		return
+	}
+
+	valueOfItem := reflect.ValueOf(item)
+	switch valueOfItem.Kind() {
+	case reflect.Array, reflect.Slice:
+		for _, i := range bounds.Indices(valueOfItem.Len()) {
+			fn(Index(i), valueOfItem.Index(i).Interface())
+		}
+
+	case reflect.Pointer, reflect.Interface:
+		if valueOfItem.IsNil() {
+			return
+		}
+		d.eachSliceElement(valueOfItem.Elem().Interface(), bounds, fn)
+	}
+}
+
+// eachDescendant calls fn for the item itself and then for all of its descendants, depth-first.
+// Pointers, maps and slices that are already being walked (cycles) are not walked again.
+func (d DefaultTraverser) eachDescendant(item any, rel []Key, walking visiting, fn func(rel []Key, value any)) {
+	fn(rel, item)
+
+	ref, entered := walking.enter(item)
+	if !entered {
+		return
+	}
+	defer walking.leave(ref)
+
+	d.eachChild(item, func(k Key, value any) {
+		d.eachDescendant(value, append(rel, k), walking, fn)
+	})
+}
+
+// eachChild calls fn for each element of a slice/array/sequence (in order), for each value of a map or a *sync.Map (ordered by key)
+// or for each addressable field of a struct (in declaration order, by its resolved name). Custom containers are iterated
+// using iter.Iterable or iter.FieldIterable (in their own order). Any other type has no children.
+func (d DefaultTraverser) eachChild(item any, fn func(k Key, value any)) {
+	// attempts to fast return without reflect.
+	if decoded, is := d.decodeEmbeddedJSON(item); is {
+		d.eachChild(decoded, fn)
+		return
+	}
+
+	switch c := item.(type) {
+	case nil:
+		return
+	case iter.Iterable:
+		_ = c.PickEach(func(index int, value any) error {
+			fn(Index(index), value)
+			return nil
+		})
+		return
+	case iter.FieldIterable:
+		_ = c.PickEachField(func(name string, value any) error {
+			fn(Field(name), value)
+			return nil
+		})
+		return
+	case *sync.Map:
+		for _, k := range syncMapKeys(c) {
+			v, _ := c.Load(k)
+			fn(Field(mapKeyAsString(reflect.ValueOf(k))), v)
+		}
+		return
+	case []any:
+		for i, v := range c {
+			fn(Index(i), v)
+		}
+		return
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(c)) {
+			fn(Field(k), c[k])
+		}
+		return
 	}
 
-	return currentItem, nil
+	valueOfItem := reflect.ValueOf(item)
+	switch valueOfItem.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := range valueOfItem.Len() {
+			fn(Index(i), valueOfItem.Index(i).Interface())
+		}
+
+	case reflect.Map:
+		keys := valueOfItem.MapKeys()
+		slices.SortFunc(keys, compareMapKeys)
+		for _, k := range keys {
+			fn(Field(mapKeyAsString(k)), valueOfItem.MapIndex(k).Interface())
+		}
+
+	case reflect.Struct:
+		for _, f := range d.structFieldsOf(valueOfItem.Type()).list {
+			if field := structFieldByIndex(valueOfItem, f.index, false); field.IsValid() {
+				fn(Field(f.name), field.Interface())
+			}
+		}
+
+	case reflect.Func, reflect.Chan:
+		if iter.IsSequence(item) {
+			d.eachChild(sequenceElements(item), fn)
+		}
+
+	case reflect.Pointer, reflect.Interface:
+		if valueOfItem.IsNil() {
+			return
+		}
+		d.eachChild(valueOfItem.Elem().Interface(), fn)
+	}
 }
 
 func (d DefaultTraverser) accessKey(item any, key Key) (any, error) {
+	v, err := d.accessKeyDirect(item, key)
+	if err != nil && d.methodGetters && key.IsField() && errors.Is(err, ErrFieldNotFound) {
+		if got, found, getterErr := d.callGetter(item, key.Name); found {
+			return got, getterErr
+		}
+	}
+
+	return v, err
+}
+
+func (d DefaultTraverser) accessKeyDirect(item any, key Key) (any, error) {
 	if item == nil {
 		return nil, ErrFieldNotFound
 	}
 
+	if decoded, is := d.decodeEmbeddedJSON(item); is {
+		return d.accessKey(decoded, key)
+	}
+
+	if t, is := item.(Traversable); is {
+		return t.PickKey(key)
+	}
+
+	if m, is := item.(*sync.Map); is {
+		return accessSyncMap(m, key)
+	}
+
 	// attempts to fast return without reflect.
 	switch key.Type {
 	case KeyTypeField:
@@ -72,6 +457,9 @@ func (d DefaultTraverser) accessKey(item any, key Key) (any, error) {
 		if m, isMap := item.(map[string]any); isMap {
 			val, found := m[key.Name]
 			if !found {
+				if k, matched := d.fuzzyMapKey(m, key.Name); matched {
+					return m[k], nil
+				}
 				return val, ErrFieldNotFound
 			}
 			return val, nil
@@ -105,6 +493,9 @@ func (d DefaultTraverser) accessKey(item any, key Key) (any, error) {
 		derefItem := d.deref(item)
 		return d.accessKey(derefItem, key)
 
+	case reflect.Func, reflect.Chan:
+		return accessSequence(item, key)
+
 	default:
 		return nil, ErrFieldNotFound
 	}
@@ -116,6 +507,61 @@ func (d DefaultTraverser) accessKey(item any, key Key) (any, error) {
 	return nil, resultError
 }
 
+// accessSyncMap loads the value of the key from the sync.Map. A field key is loaded by its name and an index key by its index (int).
+func accessSyncMap(m *sync.Map, key Key) (any, error) {
+	var k any
+	switch {
+	case key.IsField():
+		k = key.Name
+	case key.IsIndex():
+		k = key.Index
+	default:
+		return nil, ErrFieldNotFound
+	}
+
+	if v, found := m.Load(k); found {
+		return v, nil
+	}
+
+	return nil, ErrFieldNotFound
+}
+
+// syncMapKeys returns the keys of the sync.Map, ordered like the keys of a map.
+func syncMapKeys(m *sync.Map) []any {
+	var keys []any
+	m.Range(func(k, _ any) bool {
+		keys = append(keys, k)
+		return true
+	})
+	slices.SortFunc(keys, func(a, b any) int { return compareMapKeys(reflect.ValueOf(a), reflect.ValueOf(b)) })
+
+	return keys
+}
+
+// accessSequence returns the element of the sequence (see iter.IsSequence) at the index of the key,
+// evaluating the sequence (or receiving from the channel) only up to that element.
+func accessSequence(item any, key Key) (any, error) {
+	if !key.IsIndex() || !iter.IsSequence(item) {
+		return nil, ErrFieldNotFound
+	}
+
+	v, found, err := iter.Nth(item, key.Index)
+	switch {
+	case err != nil:
+		return nil, err
+	case !found:
+		return nil, ErrIndexOutOfRange
+	default:
+		return v, nil
+	}
+}
+
+// sequenceElements evaluates a sequence (or a custom collection) and returns its elements.
+func sequenceElements(item any) []any {
+	elements, _ := iter.Map(item, iter.MapOpFn(func(item any) (any, error) { return item, nil }))
+	return elements
+}
+
 func (d DefaultTraverser) getValueFromMap(typeOfItem reflect.Type, _ reflect.Kind, item any, key Key) (returnValue reflect.Value, err error) {
 	defer errorsx.RecoverPanicToError(&err)
 
@@ -127,7 +573,12 @@ func (d DefaultTraverser) getValueFromMap(typeOfItem reflect.Type, _ reflect.Kin
 
 	switch {
 	case kindOfMapKey == reflect.String && key.IsField():
-		resultValue = valueOfItem.MapIndex(reflect.ValueOf(key.Name))
+		resultValue = valueOfItem.MapIndex(reflect.ValueOf(key.Name).Convert(typeOfItem.Key()))
+		if !resultValue.IsValid() {
+			if k, matched := d.fuzzyReflectMapKey(valueOfItem, key.Name); matched {
+				resultValue = valueOfItem.MapIndex(k)
+			}
+		}
 	case kindOfMapKey == reflect.String && key.IsIndex():
 		k := strconv.Itoa(key.Index)
 		resultValue = valueOfItem.MapIndex(reflect.ValueOf(k))
@@ -152,9 +603,9 @@ func (d DefaultTraverser) getValueFromSlice(item any, key Key) (returnValue refl
 	case KeyTypeIndex:
 		index = key.Index
 	case KeyTypeField:
-		i, er := d.keyConverter.AsInt(key.Name)
+		i, er := d.fieldIndex(key.Name)
 		if er != nil {
-			return d.nilVal, errors.Join(ErrKeyConvert, er)
+			return d.nilVal, er
 		}
 		index = i
 	default:
@@ -172,16 +623,7 @@ func (d DefaultTraverser) getValueFromSlice(item any, key Key) (returnValue refl
 func (d DefaultTraverser) getValueFromStruct(item any, key Key) (returnValue reflect.Value, err error) {
 	defer errorsx.RecoverPanicToError(&err)
 
-	valueOfItem := reflect.ValueOf(item)
-
-	var resultValue reflect.Value
-
-	if key.IsIndex() {
-		resultValue = valueOfItem.Field(key.Index)
-	} else if key.IsField() {
-		resultValue = valueOfItem.FieldByName(key.Name)
-	}
-
+	resultValue := d.structFieldByKey(reflect.ValueOf(item), key, false)
 	if !resultValue.IsValid() {
 		return d.nilVal, ErrFieldNotFound
 	}
@@ -192,9 +634,34 @@ func (d DefaultTraverser) getValueFromStruct(item any, key Key) (returnValue ref
 func (d DefaultTraverser) deref(item any) any {
 	valueOfItem := reflect.ValueOf(item)
 	targetValue := valueOfItem.Elem()
+	if !targetValue.IsValid() { // nil pointer/interface
+		return nil
+	}
 	return targetValue.Interface()
 }
 
+// compareMapKeys orders map keys numerically when they are numbers, or else by their string representation.
+func compareMapKeys(a, b reflect.Value) int {
+	switch {
+	case a.CanInt() && b.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint() && b.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanFloat() && b.CanFloat():
+		return cmp.Compare(a.Float(), b.Float())
+	default:
+		return strings.Compare(mapKeyAsString(a), mapKeyAsString(b))
+	}
+}
+
+func mapKeyAsString(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+
+	return fmt.Sprint(k.Interface())
+}
+
 func attemptAccessSliceOfBasicType(sl any, index int) (handled bool, val any, err error) {
 	key := Index(index)
 
@@ -247,6 +714,7 @@ func accessSlice[T any](sl []T, key Key) (any, error) {
 
 type TraverseError struct {
 	inner      error
+	notation   Notation
 	msg        string
 	path       []Key
 	fieldIndex int
@@ -267,13 +735,28 @@ func (t *TraverseError) Unwrap() error {
 
 func (t *TraverseError) Error() string {
 	if t.inner != nil {
-		return fmt.Sprintf("selector: %s : %s: %s", formatPath(t.path[:t.fieldIndex+1]), t.msg, t.inner.Error())
+		return fmt.Sprintf("selector: %s : %s: %s", t.formatPath(), t.msg, t.inner.Error())
 	}
-	return fmt.Sprintf("selector: %s : %s", formatPath(t.path[:t.fieldIndex+1]), t.msg)
+	return fmt.Sprintf("selector: %s : %s", t.formatPath(), t.msg)
+}
+
+// WithNotation sets the notation that is used to render the path in the error message (default is the dot notation).
+func (t *TraverseError) WithNotation(n Notation) *TraverseError {
+	t.notation = n
+	return t
+}
+
+func (t *TraverseError) formatPath() string {
+	if t.notation != nil {
+		return t.notation.Format(t.Path()...)
+	}
+
+	return formatPath(t.Path())
 }
 
 func (t *TraverseError) Path() []Key {
-	return t.path[:t.fieldIndex+1]
+	// a copy, since the path might be shared (e.g. by a compiled selector or the selector cache).
+	return slices.Clone(t.path[:t.fieldIndex+1])
 }
 
 var (
@@ -0,0 +1,69 @@
+package pick
+
+import (
+	"encoding/json"
+
+	"github.com/moukoublen/pick/internal/lru"
+)
+
+// WithEmbeddedJSON enables traversing into JSON documents (objects or arrays) that are embedded in `string`, `[]byte` or `json.RawMessage` values.
+// When a key is applied to such a value, the document is decoded (like WrapJSON does) and the key is applied to the decoded value,
+// so e.g. `envelope.body.order.id` works even if `body` is a string that holds a JSON object. The value itself is returned as is.
+// The most recently used documents (up to capacity, where a capacity less than 1 is treated as 1) are cached by their content in the traverser
+// (so they are shared by the picker that uses it and the pickers it wraps), which means that a document that is traversed repeatedly is usually
+// decoded once and that the values that are retrieved from it are shared and should not be modified. Invalid documents are not cached.
+func WithEmbeddedJSON(capacity int) DefaultTraverserOption {
+	return func(d *DefaultTraverser) {
+		d.embeddedJSON = lru.New[string, any](capacity)
+	}
+}
+
+// decodeEmbeddedJSON returns the decoded document if embedded JSON decoding is enabled and the item holds a JSON object or array.
+func (d DefaultTraverser) decodeEmbeddedJSON(item any) (any, bool) {
+	if d.embeddedJSON == nil {
+		return nil, false
+	}
+
+	switch c := item.(type) {
+	case string:
+		return decodeEmbedded(d.embeddedJSON, c)
+	case []byte:
+		return decodeEmbedded(d.embeddedJSON, c)
+	case json.RawMessage:
+		return decodeEmbedded(d.embeddedJSON, []byte(c))
+	default:
+		return nil, false
+	}
+}
+
+func decodeEmbedded[T string | []byte](c *lru.Cache[string, any], raw T) (any, bool) {
+	// trims the leading and trailing white space, without copying the content.
+	start, end := 0, len(raw)
+	for start < end && isJSONSpace(raw[start]) {
+		start++
+	}
+	for end > start && isJSONSpace(raw[end-1]) {
+		end--
+	}
+	raw = raw[start:end]
+	if len(raw) == 0 || (raw[0] != '{' && raw[0] != '[') {
+		return nil, false
+	}
+
+	key := string(raw)
+	if value, found := c.Get(key); found {
+		return value, true
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, false
+	}
+
+	return c.Add(key, value), true
+}
+
+// isJSONSpace returns true for the insignificant white space characters of JSON.
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,132 @@
+package pick
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTraverserEmbeddedJSON(t *testing.T) {
+	type envelope struct {
+		Raw  json.RawMessage `json:"raw"`
+		Body string          `json:"body"`
+	}
+
+	data := map[string]any{
+		"envelope": envelope{
+			Body: `{"order": {"id": 42, "items": [{"sku": "a"}, {"sku": "b"}]}}`,
+			Raw:  json.RawMessage(`[1, 2, 3]`),
+		},
+		"bytes":  []byte(` {"nested": "{\"deep\": true}"} `),
+		"plain":  "hello",
+		"broken": `{"a": `,
+	}
+
+	tests := map[string]struct {
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		selector      string
+	}{
+		"string field": {
+			selector:      "envelope.body.order.id",
+			expected:      float64(42),
+			errorAsserter: tst.NoError(),
+		},
+		"string wildcard": {
+			selector:      "envelope.body.order.items[*].sku",
+			expected:      []any{"a", "b"},
+			errorAsserter: tst.NoError(),
+		},
+		"raw message index": {
+			selector:      "envelope.raw[1]",
+			expected:      float64(2),
+			errorAsserter: tst.NoError(),
+		},
+		"raw message slice": {
+			selector:      "envelope.raw[1:]",
+			expected:      []any{float64(2), float64(3)},
+			errorAsserter: tst.NoError(),
+		},
+		"bytes nested twice": {
+			selector:      "bytes.nested.deep",
+			expected:      true,
+			errorAsserter: tst.NoError(),
+		},
+		"recursive descent": {
+			selector:      "envelope..sku",
+			expected:      []any{"a", "b"},
+			errorAsserter: tst.NoError(),
+		},
+		"value is returned as is": {
+			selector:      "envelope.raw",
+			expected:      json.RawMessage(`[1, 2, 3]`),
+			errorAsserter: tst.NoError(),
+		},
+		"not json": {
+			selector:      "plain.a",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"invalid json": {
+			selector:      "broken.a",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+	}
+
+	converter := NewDefaultConverter()
+	p := NewPicker(data, NewDefaultTraverser(converter, WithEmbeddedJSON(8)), converter, DotNotation{})
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := p.Any(tc.selector)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	t.Run("typed access", func(t *testing.T) {
+		id, err := p.Int("envelope.body.order.id")
+		require.NoError(t, err)
+		testingx.AssertEqual(t, id, 42)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		_, err := Wrap(data).Any("envelope.body.order.id")
+		tst.ErrorIs(ErrFieldNotFound)(t, err)
+	})
+
+	t.Run("memoized", func(t *testing.T) {
+		d := NewDefaultTraverser(converter, WithEmbeddedJSON(8))
+		first, found := d.decodeEmbeddedJSON(`{"a": {"b": 1}}`)
+		require.True(t, found)
+		second, found := d.decodeEmbeddedJSON([]byte(`{"a": {"b": 1}}`))
+		require.True(t, found)
+
+		// the same decoded map is returned.
+		first.(map[string]any)["c"] = 2                          //nolint:forcetypeassert
+		testingx.AssertEqual(t, second.(map[string]any)["c"], 2) //nolint:forcetypeassert
+	})
+
+	t.Run("bounded and only valid documents", func(t *testing.T) {
+		d := NewDefaultTraverser(converter, WithEmbeddedJSON(8))
+		_, found := d.decodeEmbeddedJSON(`{"a": `)
+		require.False(t, found)
+		testingx.AssertEqual(t, d.embeddedJSON.Len(), 0)
+
+		for i := range 18 {
+			_, found := d.decodeEmbeddedJSON(fmt.Sprintf(`{"a": %d}`, i))
+			require.True(t, found)
+		}
+		testingx.AssertEqual(t, d.embeddedJSON.Len(), 8)
+
+		// the least recently used documents are evicted.
+		_, found = d.embeddedJSON.Get(`{"a": 0}`)
+		require.False(t, found)
+		_, found = d.embeddedJSON.Get(`{"a": 17}`)
+		require.True(t, found)
+	})
+}
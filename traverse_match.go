@@ -0,0 +1,97 @@
+package pick
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// FieldMatching is the strategy that the DefaultTraverser uses to match field keys to map keys and struct fields.
+// Any strategy other than the exact one is used only when there is no exact match.
+type FieldMatching int
+
+const (
+	// FieldMatchExact matches only identical names (default).
+	FieldMatchExact FieldMatching = iota
+	// FieldMatchCaseInsensitive matches names that are equal ignoring case (e.g. `userid` matches `userId` and `UserID`).
+	FieldMatchCaseInsensitive
+	// FieldMatchNormalized matches names that are equal ignoring case and the `_`, `-` and space separators,
+	// so snake, camel and kebab case names match each other (e.g. `user_id` matches `userId`, `UserID` and `user-id`).
+	FieldMatchNormalized
+)
+
+// WithFieldMatching sets the strategy that is used to match field keys when there is no exact match. The default is FieldMatchExact.
+func WithFieldMatching(m FieldMatching) DefaultTraverserOption {
+	return func(d *DefaultTraverser) {
+		d.fieldMatching = m
+	}
+}
+
+// fold returns the form of the name that is compared by the strategy.
+func (m FieldMatching) fold(name string) string {
+	switch m {
+	case FieldMatchCaseInsensitive:
+		return strings.ToLower(name)
+	case FieldMatchNormalized:
+		return strings.Map(func(r rune) rune {
+			if r == '_' || r == '-' || r == ' ' {
+				return -1
+			}
+			return unicode.ToLower(r)
+		}, name)
+	default:
+		return name
+	}
+}
+
+// fuzzyMapKey returns the smallest key (in sorted order) of the map that matches the name using the traverser's (non exact) field matching.
+func (d DefaultTraverser) fuzzyMapKey(m map[string]any, name string) (string, bool) {
+	if d.fieldMatching == FieldMatchExact {
+		return "", false
+	}
+
+	folded := d.fieldMatching.fold(name)
+	match, found := "", false
+	for k := range m {
+		if (!found || k < match) && d.fieldMatching.fold(k) == folded {
+			match, found = k, true
+		}
+	}
+
+	return match, found
+}
+
+// fuzzyReflectMapKey is like fuzzyMapKey for maps (of any type) that have string keys.
+func (d DefaultTraverser) fuzzyReflectMapKey(m reflect.Value, name string) (reflect.Value, bool) {
+	if d.fieldMatching == FieldMatchExact || m.Type().Key().Kind() != reflect.String {
+		return d.nilVal, false
+	}
+
+	folded := d.fieldMatching.fold(name)
+	match, found := d.nilVal, false
+	for it := m.MapRange(); it.Next(); {
+		k := it.Key()
+		if (!found || k.String() < match.String()) && d.fieldMatching.fold(k.String()) == folded {
+			match, found = k, true
+		}
+	}
+
+	return match, found
+}
+
+// fuzzyStructField returns the first field (in declaration order, tag names before Go names) that matches the name
+// using the traverser's (non exact) field matching.
+func (d DefaultTraverser) fuzzyStructField(fields *structFields, name string) (structField, bool) {
+	if d.fieldMatching == FieldMatchExact {
+		return structField{}, false
+	}
+
+	folded := d.fieldMatching.fold(name)
+	for _, f := range fields.names {
+		if d.fieldMatching.fold(f.name) == folded {
+			return f, true
+		}
+	}
+
+	return structField{}, false
+}
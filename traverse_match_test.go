@@ -0,0 +1,168 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldMatchingFold(t *testing.T) {
+	tests := map[string]struct {
+		matching FieldMatching
+		names    []string
+		expected string
+	}{
+		"exact": {
+			matching: FieldMatchExact,
+			names:    []string{"userId"},
+			expected: "userId",
+		},
+		"case insensitive": {
+			matching: FieldMatchCaseInsensitive,
+			names:    []string{"userid", "userId", "UserID", "USERID"},
+			expected: "userid",
+		},
+		"normalized": {
+			matching: FieldMatchNormalized,
+			names:    []string{"user_id", "userId", "UserID", "user-id", "User Id", "USER_ID"},
+			expected: "userid",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range tc.names {
+				testingx.AssertEqual(t, tc.matching.fold(n), tc.expected)
+			}
+		})
+	}
+}
+
+func TestDefaultTraverserFieldMatching(t *testing.T) {
+	type user struct {
+		UserID   int    `json:"userId"`
+		FullName string `json:"full_name"`
+	}
+	type named string
+
+	tests := map[string]struct {
+		input         any
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+		matching      FieldMatching
+	}{
+		"exact does not match": {
+			input:         map[string]any{"userId": 1},
+			keys:          []Key{Field("user_id")},
+			matching:      FieldMatchExact,
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"case insensitive map": {
+			input:         map[string]any{"userId": 1},
+			keys:          []Key{Field("USERID")},
+			matching:      FieldMatchCaseInsensitive,
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"case insensitive does not normalize": {
+			input:         map[string]any{"userId": 1},
+			keys:          []Key{Field("user_id")},
+			matching:      FieldMatchCaseInsensitive,
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"normalized map": {
+			input:         map[string]any{"userId": 1},
+			keys:          []Key{Field("user_id")},
+			matching:      FieldMatchNormalized,
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"exact match is preferred": {
+			input:         map[string]any{"UserID": 1, "user_id": 2, "userId": 3},
+			keys:          []Key{Field("userId")},
+			matching:      FieldMatchNormalized,
+			expected:      3,
+			errorAsserter: tst.NoError(),
+		},
+		"first sorted key wins": {
+			input:         map[string]any{"user_id": 2, "UserID": 1},
+			keys:          []Key{Field("userId")},
+			matching:      FieldMatchNormalized,
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"typed map": {
+			input:         map[named]int{"user-id": 1},
+			keys:          []Key{Field("UserId")},
+			matching:      FieldMatchNormalized,
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"typed map first sorted key wins": {
+			input:         map[named]int{"user_id": 2, "user-id": 3, "UserID": 1},
+			keys:          []Key{Field("userId")},
+			matching:      FieldMatchNormalized,
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"struct tag name": {
+			input:         user{UserID: 1, FullName: "a"},
+			keys:          []Key{Field("fullName")},
+			matching:      FieldMatchNormalized,
+			expected:      "a",
+			errorAsserter: tst.NoError(),
+		},
+		"struct go name": {
+			input:         user{UserID: 1, FullName: "a"},
+			keys:          []Key{Field("user_id")},
+			matching:      FieldMatchNormalized,
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"struct case insensitive": {
+			input:         &user{UserID: 1, FullName: "a"},
+			keys:          []Key{Field("FULL_NAME")},
+			matching:      FieldMatchCaseInsensitive,
+			expected:      "a",
+			errorAsserter: tst.NoError(),
+		},
+		"nested": {
+			input:         map[string]any{"Data": map[string]any{"user-list": []any{map[string]any{"ID": 7}}}},
+			keys:          []Key{Field("data"), Field("userList"), Index(0), Field("id")},
+			matching:      FieldMatchNormalized,
+			expected:      7,
+			errorAsserter: tst.NoError(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr := NewDefaultTraverser(NewDefaultConverter(), WithFieldMatching(tc.matching))
+			got, err := tr.Retrieve(tc.input, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+}
+
+func TestPickerFieldMatching(t *testing.T) {
+	c := NewDefaultConverter()
+	data := map[string]any{"userId": 1, "meta": map[string]string{"Request-ID": "r"}}
+	p := NewPicker(data, NewDefaultTraverser(c, WithFieldMatching(FieldMatchNormalized)), c, DotNotation{})
+
+	id, err := p.Int("user_id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, id, 1)
+
+	// writes update the matching key instead of adding a new one.
+	require.NoError(t, p.Set("UserID", 2))
+	require.NoError(t, p.Set("meta.request_id", "x"))
+	require.NoError(t, p.Delete("META.requestId"))
+	require.NoError(t, p.Set("new_field", true))
+	testingx.AssertEqual(t, p.Data(), any(map[string]any{"userId": 2, "meta": map[string]string{}, "new_field": true}))
+}
@@ -0,0 +1,332 @@
+package pick
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+
+	"github.com/moukoublen/pick/internal/errorsx"
+)
+
+// updateFunc receives the current value of the last key of the path (and whether it exists) and returns the new value.
+// Returning removeMarker removes the key from its parent.
+type updateFunc func(current any, found bool) (any, error)
+
+// updateOp describes an update of the value of the last key of a path.
+type updateOp struct {
+	fn     updateFunc
+	msg    string // the message of the TraverseError.
+	create bool   // if true, missing intermediate values are created.
+}
+
+// removeMarker is returned by an updateFunc in order to remove the key from its parent.
+type removeMarker struct{}
+
+// Set sets the value in the path and returns the data, which might be a new value (e.g. if data is nil or if a slice had to grow).
+// Intermediate maps (`map[string]any`) and slices (`[]any`) are created if they do not exist, slices grow if the index is after the end
+// and negative indices are relative to the end. Maps, slices and pointers are written in place, while structs and arrays are copied
+// (since they are not addressable) and the copy is written back to their parent.
+// Only field and index keys are supported.
+func (d DefaultTraverser) Set(data any, path []Key, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	op := updateOp{
+		fn:     func(any, bool) (any, error) { return value, nil },
+		msg:    "error trying to set",
+		create: true,
+	}
+
+	return d.update(data, path, 0, op)
+}
+
+// Delete removes the last key of the path and returns the data, which might be a new value (e.g. if the root slice shrinks).
+// Map keys are deleted and slice elements are spliced. Struct fields and array elements cannot be removed, so they are reset to their zero value.
+// Only field and index keys are supported.
+func (d DefaultTraverser) Delete(data any, path []Key) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	op := updateOp{
+		fn: func(_ any, found bool) (any, error) {
+			if !found {
+				return nil, ErrFieldNotFound
+			}
+			return removeMarker{}, nil
+		},
+		msg:    "error trying to delete",
+		create: false,
+	}
+
+	return d.update(data, path, 0, op)
+}
+
+// update replaces the value of the key `path[i]` of the item with the result of the next level (or of op.fn for the last key)
+// and returns the item, which might be a new value.
+func (d DefaultTraverser) update(item any, path []Key, i int, op updateOp) (any, error) {
+	key := path[i]
+	if !key.IsField() && !key.IsIndex() {
+		return item, NewTraverseError(op.msg, path, i, ErrKeyUnknown)
+	}
+
+	next := func(child any, found bool) (any, error) {
+		if i+1 < len(path) {
+			if !found && !op.create {
+				return child, NewTraverseError(op.msg, path, i, ErrFieldNotFound)
+			}
+			return d.update(child, path, i+1, op)
+		}
+
+		v, err := op.fn(child, found)
+		if err != nil {
+			return child, NewTraverseError(op.msg, path, i, err)
+		}
+		return v, nil
+	}
+
+	if item == nil {
+		if !op.create {
+			return item, NewTraverseError(op.msg, path, i, ErrFieldNotFound)
+		}
+		if key.IsField() {
+			item = map[string]any{}
+		} else {
+			item = []any{}
+		}
+	}
+
+	// attempts to fast return without reflect.
+	switch c := item.(type) {
+	case map[string]any:
+		if key.IsField() {
+			if c == nil {
+				if !op.create {
+					return item, NewTraverseError(op.msg, path, i, ErrFieldNotFound)
+				}
+				c = map[string]any{}
+			}
+			name := key.Name
+			child, found := c[name]
+			if !found {
+				if k, matched := d.fuzzyMapKey(c, name); matched {
+					name, child, found = k, c[k], true
+				}
+			}
+			newChild, err := next(child, found)
+			if err != nil {
+				return item, err
+			}
+			if _, remove := newChild.(removeMarker); remove {
+				delete(c, name)
+				return c, nil
+			}
+			c[name] = newChild
+			return c, nil
+		}
+
+	case []any:
+		if key.IsIndex() {
+			idx, err := indexForUpdate(key.Index, len(c), op.create)
+			if err != nil {
+				return item, NewTraverseError(op.msg, path, i, err)
+			}
+			found := idx < len(c)
+			if !found {
+				c = append(c, make([]any, idx+1-len(c))...)
+			}
+			newChild, err := next(c[idx], found)
+			if err != nil {
+				return item, err
+			}
+			if _, remove := newChild.(removeMarker); remove {
+				return slices.Delete(c, idx, idx+1), nil
+			}
+			c[idx] = newChild
+			return c, nil
+		}
+	}
+
+	return d.updateReflect(item, path, i, op, next)
+}
+
+func (d DefaultTraverser) updateReflect(item any, path []Key, i int, op updateOp, next updateFunc) (result any, err error) {
+	defer func() {
+		var te *TraverseError
+		if err != nil && !errors.As(err, &te) {
+			result, err = item, NewTraverseError(op.msg, path, i, err)
+		}
+	}()
+	defer errorsx.RecoverPanicToError(&err)
+
+	key := path[i]
+	valueOfItem := reflect.ValueOf(item)
+	typeOfItem := valueOfItem.Type()
+
+	switch valueOfItem.Kind() {
+	case reflect.Pointer: // write through the pointer, using the same key on the target.
+		if valueOfItem.IsNil() {
+			if !op.create {
+				return item, ErrFieldNotFound
+			}
+			valueOfItem = reflect.New(typeOfItem.Elem())
+		}
+		elem := valueOfItem.Elem()
+		newElem, err := d.update(elem.Interface(), path, i, op)
+		if err != nil {
+			return item, err
+		}
+		if err := d.assign(elem, newElem); err != nil {
+			return item, err
+		}
+		return valueOfItem.Interface(), nil
+
+	case reflect.Map:
+		mapKey, err := d.mapKey(typeOfItem, key)
+		if err != nil {
+			return item, err
+		}
+		if valueOfItem.IsNil() {
+			if !op.create {
+				return item, ErrFieldNotFound
+			}
+			valueOfItem = reflect.MakeMap(typeOfItem)
+		}
+		var child any
+		childValue := valueOfItem.MapIndex(mapKey)
+		if !childValue.IsValid() && key.IsField() {
+			if k, matched := d.fuzzyReflectMapKey(valueOfItem, key.Name); matched {
+				mapKey, childValue = k, valueOfItem.MapIndex(k)
+			}
+		}
+		if childValue.IsValid() {
+			child = childValue.Interface()
+		}
+		newChild, err := next(child, childValue.IsValid())
+		if err != nil {
+			return item, err
+		}
+		if _, remove := newChild.(removeMarker); remove {
+			valueOfItem.SetMapIndex(mapKey, d.nilVal)
+			return valueOfItem.Interface(), nil
+		}
+		elem := reflect.New(typeOfItem.Elem()).Elem()
+		if err := d.assign(elem, newChild); err != nil {
+			return item, err
+		}
+		valueOfItem.SetMapIndex(mapKey, elem)
+		return valueOfItem.Interface(), nil
+
+	case reflect.Slice, reflect.Array:
+		index, err := d.sliceIndex(key)
+		if err != nil {
+			return item, err
+		}
+		grow := op.create && valueOfItem.Kind() == reflect.Slice
+		idx, err := indexForUpdate(index, valueOfItem.Len(), grow)
+		if err != nil {
+			return item, err
+		}
+		found := idx < valueOfItem.Len()
+		if valueOfItem.Kind() == reflect.Array {
+			valueOfItem = addressableCopy(valueOfItem)
+		} else if !found {
+			valueOfItem = reflect.AppendSlice(valueOfItem, reflect.MakeSlice(typeOfItem, idx+1-valueOfItem.Len(), idx+1-valueOfItem.Len()))
+		}
+		elem := valueOfItem.Index(idx)
+		newChild, err := next(elem.Interface(), found)
+		if err != nil {
+			return item, err
+		}
+		if _, remove := newChild.(removeMarker); remove && valueOfItem.Kind() == reflect.Slice {
+			return reflect.AppendSlice(valueOfItem.Slice(0, idx), valueOfItem.Slice(idx+1, valueOfItem.Len())).Interface(), nil
+		}
+		if err := d.assign(elem, newChild); err != nil {
+			return item, err
+		}
+		return valueOfItem.Interface(), nil
+
+	case reflect.Struct:
+		valueOfItem = addressableCopy(valueOfItem)
+		field := d.structFieldByKey(valueOfItem, key, true)
+		if !field.IsValid() || !field.CanSet() {
+			return item, ErrFieldNotFound
+		}
+		newChild, err := next(field.Interface(), true)
+		if err != nil {
+			return item, err
+		}
+		if err := d.assign(field, newChild); err != nil {
+			return item, err
+		}
+		return valueOfItem.Interface(), nil
+
+	default:
+		return item, ErrInvalidSetTarget
+	}
+}
+
+// assign sets the value to the destination, converting it to the type of the destination if needed.
+// A nil value or a removeMarker reset the destination to its zero value.
+func (d DefaultTraverser) assign(dst reflect.Value, value any) error {
+	if _, remove := value.(removeMarker); value == nil || remove {
+		dst.SetZero()
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(dst.Type()) {
+		converted, err := d.keyConverter.ByType(value, dst.Type())
+		if err != nil {
+			return err
+		}
+		v = reflect.ValueOf(converted)
+	}
+	dst.Set(v)
+
+	return nil
+}
+
+// mapKey returns the key as a value of the key type of the map.
+func (d DefaultTraverser) mapKey(typeOfMap reflect.Type, key Key) (reflect.Value, error) {
+	k, err := d.keyConverter.ByType(key.Any(), typeOfMap.Key())
+	if err != nil {
+		return d.nilVal, errors.Join(ErrKeyConvert, err)
+	}
+
+	return reflect.ValueOf(k), nil
+}
+
+// sliceIndex returns the index of an index key, or the index that a field key is converted to.
+func (d DefaultTraverser) sliceIndex(key Key) (int, error) {
+	if key.IsIndex() {
+		return key.Index, nil
+	}
+
+	return d.fieldIndex(key.Name)
+}
+
+// indexForUpdate resolves negative indices relatively to the end. If grow is true, indices after the end are allowed.
+func indexForUpdate(index, length int, grow bool) (int, error) {
+	i := relativeIndex(index, length)
+	if i < 0 || (!grow && i >= length) {
+		return i, ErrIndexOutOfRange
+	}
+
+	return i, nil
+}
+
+// addressableCopy returns an addressable copy of a (non addressable) struct or array value.
+func addressableCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+var (
+	ErrInvalidSetTarget   = errors.New("value is not a collection")
+	ErrSetNotSupported    = errors.New("traverser does not support set")
+	ErrDeleteNotSupported = errors.New("traverser does not support delete")
+	ErrMoveIntoItself     = errors.New("cannot move a value into itself")
+)
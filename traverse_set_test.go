@@ -0,0 +1,367 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTraverserSet(t *testing.T) {
+	type inner struct {
+		Value int
+		Tags  []string
+		Attrs map[string]any
+	}
+	type outer struct {
+		Inner    inner
+		InnerPtr *inner
+	}
+
+	tests := map[string]struct {
+		input         func() any
+		value         any
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+	}{
+		"empty path replaces data": {
+			input:         func() any { return map[string]any{"a": 1} },
+			keys:          nil,
+			value:         "x",
+			expected:      "x",
+			errorAsserter: tst.NoError(),
+		},
+		"nil data creates maps": {
+			input:         func() any { return nil },
+			keys:          []Key{Field("a"), Field("b")},
+			value:         1,
+			expected:      map[string]any{"a": map[string]any{"b": 1}},
+			errorAsserter: tst.NoError(),
+		},
+		"nil data creates slices": {
+			input:         func() any { return nil },
+			keys:          []Key{Index(1), Field("a")},
+			value:         1,
+			expected:      []any{nil, map[string]any{"a": 1}},
+			errorAsserter: tst.NoError(),
+		},
+		"replace existing": {
+			input:         func() any { return map[string]any{"a": []any{1, 2, 3}} },
+			keys:          []Key{Field("a"), Index(1)},
+			value:         "two",
+			expected:      map[string]any{"a": []any{1, "two", 3}},
+			errorAsserter: tst.NoError(),
+		},
+		"negative index": {
+			input:         func() any { return map[string]any{"a": []any{1, 2, 3}} },
+			keys:          []Key{Field("a"), Index(-1)},
+			value:         "three",
+			expected:      map[string]any{"a": []any{1, 2, "three"}},
+			errorAsserter: tst.NoError(),
+		},
+		"negative index out of range": {
+			input:         func() any { return []any{1} },
+			keys:          []Key{Index(-2)},
+			value:         0,
+			expected:      []any{1},
+			errorAsserter: tst.ErrorIs(ErrIndexOutOfRange),
+		},
+		"grow slice": {
+			input:         func() any { return map[string]any{"a": []any{1}} },
+			keys:          []Key{Field("a"), Index(3)},
+			value:         4,
+			expected:      map[string]any{"a": []any{1, nil, nil, 4}},
+			errorAsserter: tst.NoError(),
+		},
+		"grow typed slice with conversion": {
+			input:         func() any { return map[string]any{"a": []int{1}} },
+			keys:          []Key{Field("a"), Index(2)},
+			value:         "3",
+			expected:      map[string]any{"a": []int{1, 0, 3}},
+			errorAsserter: tst.NoError(),
+		},
+		"typed map with intermediate creation": {
+			input:         func() any { return map[string]map[string]int{} },
+			keys:          []Key{Field("a"), Field("b")},
+			value:         2,
+			expected:      map[string]map[string]int{"a": {"b": 2}},
+			errorAsserter: tst.NoError(),
+		},
+		"map with int keys": {
+			input:         func() any { return map[int]string{1: "one"} },
+			keys:          []Key{Index(2)},
+			value:         "two",
+			expected:      map[int]string{1: "one", 2: "two"},
+			errorAsserter: tst.NoError(),
+		},
+		"struct copy": {
+			input:         func() any { return outer{} },
+			keys:          []Key{Field("Inner"), Field("Tags"), Index(1)},
+			value:         "b",
+			expected:      outer{Inner: inner{Tags: []string{"", "b"}}},
+			errorAsserter: tst.NoError(),
+		},
+		"struct nil pointer and nil map are created": {
+			input:         func() any { return &outer{} },
+			keys:          []Key{Field("InnerPtr"), Field("Attrs"), Field("x")},
+			value:         true,
+			expected:      &outer{InnerPtr: &inner{Attrs: map[string]any{"x": true}}},
+			errorAsserter: tst.NoError(),
+		},
+		"array": {
+			input:         func() any { return [2]int{1, 2} },
+			keys:          []Key{Index(-1)},
+			value:         5,
+			expected:      [2]int{1, 5},
+			errorAsserter: tst.NoError(),
+		},
+		"array does not grow": {
+			input:         func() any { return [2]int{1, 2} },
+			keys:          []Key{Index(2)},
+			value:         5,
+			expected:      [2]int{1, 2},
+			errorAsserter: tst.ErrorIs(ErrIndexOutOfRange),
+		},
+		"conversion error": {
+			input:         func() any { return map[string]int{} },
+			keys:          []Key{Field("a")},
+			value:         "abc",
+			expected:      map[string]int{},
+			errorAsserter: tst.ErrorIs(ErrConvertInvalidSyntax),
+		},
+		"scalar target": {
+			input:         func() any { return map[string]any{"a": "str"} },
+			keys:          []Key{Field("a"), Field("b")},
+			value:         1,
+			expected:      map[string]any{"a": "str"},
+			errorAsserter: tst.ErrorIs(ErrInvalidSetTarget),
+		},
+		"unsupported key": {
+			input:         func() any { return []any{1} },
+			keys:          []Key{Wildcard()},
+			value:         1,
+			expected:      []any{1},
+			errorAsserter: tst.ErrorIs(ErrKeyUnknown),
+		},
+	}
+
+	tr := NewDefaultTraverser(NewDefaultConverter())
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tr.Set(tc.input(), tc.keys, tc.value)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	type withPrivate struct {
+		private int
+	}
+	_, err := tr.Set(withPrivate{}, []Key{Field("private")}, 1)
+	tst.ErrorIs(ErrFieldNotFound)(t, err)
+}
+
+func TestDefaultTraverserSetWritesThroughPointers(t *testing.T) {
+	type item struct {
+		Name string
+	}
+
+	it := &item{Name: "a"}
+	data := map[string]any{"items": []*item{it}}
+
+	got, err := NewDefaultTraverser(NewDefaultConverter()).Set(data, []Key{Field("items"), Index(0), Field("Name")}, "b")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, it.Name, "b")
+	testingx.AssertEqual(t, got, any(data))
+}
+
+func TestPickerSet(t *testing.T) {
+	p := Wrap(nil)
+	require.NoError(t, p.Set("user.name", "alice"))
+	require.NoError(t, p.Set("user.tags[1]", "b"))
+	require.NoError(t, p.Set("user.tags[-2]", "a"))
+	require.NoError(t, p.SetPath([]Key{Field("user"), Field("a.b")}, 1))
+	testingx.AssertEqual(t, p.Data(), any(map[string]any{
+		"user": map[string]any{
+			"name": "alice",
+			"tags": []any{"a", "b"},
+			"a.b":  1,
+		},
+	}))
+
+	name, err := p.String("user.name")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, name, "alice")
+
+	err = p.Set("user.name.first", "x")
+	tst.ErrorIs(ErrInvalidSetTarget)(t, err)
+	testingx.AssertEqual(t, err.Error(), "selector: user.name.first : error trying to set: value is not a collection")
+
+	err = p.Set("user[", "x")
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+
+	// the root slice grows.
+	sl := Wrap([]any{1})
+	require.NoError(t, sl.Set("[2]", 3))
+	testingx.AssertEqual(t, sl.Data(), any([]any{1, nil, 3}))
+
+	type readOnly struct{ Traverser }
+	ro := NewPicker(nil, readOnly{NewDefaultTraverser(NewDefaultConverter())}, NewDefaultConverter(), DotNotation{})
+	tst.ErrorIs(ErrSetNotSupported)(t, ro.Set("a", 1))
+}
+
+func TestDefaultTraverserDelete(t *testing.T) {
+	type inner struct {
+		Value int
+		Tags  []string
+	}
+
+	tests := map[string]struct {
+		input         func() any
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+	}{
+		"empty path removes data": {
+			input:         func() any { return map[string]any{"a": 1} },
+			keys:          nil,
+			expected:      nil,
+			errorAsserter: tst.NoError(),
+		},
+		"map key": {
+			input:         func() any { return map[string]any{"a": map[string]any{"b": 1, "c": 2}} },
+			keys:          []Key{Field("a"), Field("b")},
+			expected:      map[string]any{"a": map[string]any{"c": 2}},
+			errorAsserter: tst.NoError(),
+		},
+		"slice element": {
+			input:         func() any { return map[string]any{"a": []any{1, 2, 3}} },
+			keys:          []Key{Field("a"), Index(1)},
+			expected:      map[string]any{"a": []any{1, 3}},
+			errorAsserter: tst.NoError(),
+		},
+		"root slice negative index": {
+			input:         func() any { return []any{1, 2, 3} },
+			keys:          []Key{Index(-1)},
+			expected:      []any{1, 2},
+			errorAsserter: tst.NoError(),
+		},
+		"typed map": {
+			input:         func() any { return map[int]string{1: "one", 2: "two"} },
+			keys:          []Key{Index(2)},
+			expected:      map[int]string{1: "one"},
+			errorAsserter: tst.NoError(),
+		},
+		"typed slice": {
+			input:         func() any { return map[string][]int{"a": {1, 2, 3}} },
+			keys:          []Key{Field("a"), Index(0)},
+			expected:      map[string][]int{"a": {2, 3}},
+			errorAsserter: tst.NoError(),
+		},
+		"struct field is reset": {
+			input:         func() any { return inner{Value: 1, Tags: []string{"a", "b"}} },
+			keys:          []Key{Field("Value")},
+			expected:      inner{Tags: []string{"a", "b"}},
+			errorAsserter: tst.NoError(),
+		},
+		"slice in struct": {
+			input:         func() any { return inner{Value: 1, Tags: []string{"a", "b"}} },
+			keys:          []Key{Field("Tags"), Index(0)},
+			expected:      inner{Value: 1, Tags: []string{"b"}},
+			errorAsserter: tst.NoError(),
+		},
+		"array element is reset": {
+			input:         func() any { return [2]int{1, 2} },
+			keys:          []Key{Index(0)},
+			expected:      [2]int{0, 2},
+			errorAsserter: tst.NoError(),
+		},
+		"missing key": {
+			input:         func() any { return map[string]any{"a": 1} },
+			keys:          []Key{Field("b")},
+			expected:      map[string]any{"a": 1},
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"missing intermediate is not created": {
+			input:         func() any { return map[string]any{"a": 1} },
+			keys:          []Key{Field("b"), Field("c")},
+			expected:      map[string]any{"a": 1},
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"index out of range": {
+			input:         func() any { return []any{1} },
+			keys:          []Key{Index(1)},
+			expected:      []any{1},
+			errorAsserter: tst.ErrorIs(ErrIndexOutOfRange),
+		},
+		"unsupported key": {
+			input:         func() any { return []any{1} },
+			keys:          []Key{Wildcard()},
+			expected:      []any{1},
+			errorAsserter: tst.ErrorIs(ErrKeyUnknown),
+		},
+	}
+
+	tr := NewDefaultTraverser(NewDefaultConverter())
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tr.Delete(tc.input(), tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+}
+
+func TestPickerDeleteMoveCopy(t *testing.T) {
+	p, err := WrapJSON([]byte(`{"user": {"name": "alice", "password": "secret", "tags": ["a", "b", "c"]}}`))
+	require.NoError(t, err)
+
+	require.NoError(t, p.Delete("user.password"))
+	require.NoError(t, p.Delete("user.tags[0]"))
+	require.NoError(t, p.Move("user.name", "user.username"))
+	require.NoError(t, p.Copy("user.tags", "tags"))
+	require.NoError(t, p.Move("user.tags[0]", "user.tags[1]"))
+	require.NoError(t, p.Move("user", "user"))
+
+	// the copy is not affected by changes of the source.
+	require.NoError(t, p.Set("user.tags[0]", "x"))
+
+	testingx.AssertEqual(t, p.Data(), any(map[string]any{
+		"user": map[string]any{
+			"username": "alice",
+			"tags":     []any{"x", "b"},
+		},
+		"tags": []any{"b", "c"},
+	}))
+
+	err = p.Delete("user.password")
+	tst.ErrorIs(ErrFieldNotFound)(t, err)
+	testingx.AssertEqual(t, err.Error(), "selector: user.password : error trying to delete: field not found")
+
+	err = p.Move("user", "user.inner")
+	tst.ErrorIs(ErrMoveIntoItself)(t, err)
+	testingx.AssertEqual(t, err.Error(), "selector: user.inner : error trying to move: cannot move a value into itself")
+
+	err = p.Move("missing", "a")
+	tst.ErrorIs(ErrFieldNotFound)(t, err)
+
+	// a failed move puts the value back to its position.
+	failed, err := WrapJSON([]byte(`{"a": 1, "b": "str", "list": [1, 2, 3]}`))
+	require.NoError(t, err)
+	tst.Error()(t, failed.Move("a", "b.c"))
+	tst.Error()(t, failed.Move("list[1]", "b.c"))
+	tst.Error()(t, failed.Move("list[-1]", "b.c"))
+	tst.Error()(t, failed.Move("list.0", "b.c"))
+	testingx.AssertEqual(t, failed.Data(), any(map[string]any{"a": float64(1), "b": "str", "list": []any{float64(1), float64(2), float64(3)}}))
+
+	err = p.Copy("tags[5]", "a")
+	tst.ErrorIs(ErrIndexOutOfRange)(t, err)
+	err = p.Copy("tags", "a[")
+	tst.ErrorIs(ErrInvalidSelectorFormatForIndex)(t, err)
+
+	type readOnly struct{ Traverser }
+	ro := NewPicker(map[string]any{"a": 1}, readOnly{NewDefaultTraverser(NewDefaultConverter())}, NewDefaultConverter(), DotNotation{})
+	tst.ErrorIs(ErrDeleteNotSupported)(t, ro.Delete("a"))
+}
@@ -0,0 +1,342 @@
+package pick
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moukoublen/pick/internal/errorsx"
+	"github.com/moukoublen/pick/internal/structtag"
+	"github.com/moukoublen/pick/iter"
+)
+
+// defaultStructTags are the struct tags that the DefaultTraverser uses by default to resolve struct fields (the same as `iter.ForEachField`).
+var defaultStructTags = structtag.DefaultTags //nolint:gochecknoglobals
+
+// defaultStructFieldCache is shared by the traversers that use the default struct tags (including the zero value DefaultTraverser).
+var defaultStructFieldCache = newStructFieldCache(defaultStructTags) //nolint:gochecknoglobals
+
+// structField is an exported struct field with the name it is addressed by.
+type structField struct {
+	name  string
+	index []int
+}
+
+// structFields holds the resolved fields of a struct type.
+type structFields struct {
+	byName map[string]structField // by tag name and by Go name.
+	list   []structField          // in declaration order, with their resolved names.
+	names  []structField          // every name that a field is addressed by, tag names (in declaration order) first.
+}
+
+// structFieldCache caches the resolved fields per struct type. It is safe for concurrent use.
+type structFieldCache struct {
+	types sync.Map // reflect.Type -> *structFields
+	tags  []string
+}
+
+func newStructFieldCache(tags []string) *structFieldCache {
+	return &structFieldCache{tags: tags}
+}
+
+func (c *structFieldCache) fields(t reflect.Type) *structFields {
+	if f, found := c.types.Load(t); found {
+		return f.(*structFields) //nolint:forcetypeassert // only *structFields are stored.
+	}
+
+	f, _ := c.types.LoadOrStore(t, resolveStructFields(t, c.tags))
+	return f.(*structFields) //nolint:forcetypeassert // only *structFields are stored.
+}
+
+// resolveStructFields resolves the exported fields of the struct type, following the visibility rules of encoding/json.
+// Each field is addressed by the name of the first tag (in tags order) that defines one, or else by its Go name.
+// Fields with a `-` tag name (e.g. `json:"-"`) are omitted. The fields of embedded structs without a tag name are promoted
+// and when multiple fields have the same name, the least nested one wins (or the tagged one among equally nested fields),
+// while if there is still a tie, none of them is addressable.
+// Tagged fields and embedded structs can also be addressed by their Go name, unless another field uses it as its name.
+func resolveStructFields(t reflect.Type, tags []string) *structFields {
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+	var candidates []structFieldCandidate
+	var goNames []structField
+	visited := map[reflect.Type]bool{}
+
+	// breadth first, so that the candidates are ordered by depth.
+	for next := []level{{typ: t}}; len(next) > 0; {
+		current := next
+		next = nil
+		for _, l := range current {
+			if visited[l.typ] {
+				continue
+			}
+			visited[l.typ] = true
+
+			for i := range l.typ.NumField() {
+				f := l.typ.Field(i)
+				embedded := f.Type
+				if embedded.Kind() == reflect.Pointer {
+					embedded = embedded.Elem()
+				}
+				switch {
+				case f.Anonymous && !f.IsExported() && (f.Type.Kind() == reflect.Pointer || embedded.Kind() != reflect.Struct):
+					continue // like encoding/json, only the embedded unexported struct values are promoted.
+				case !f.Anonymous && !f.IsExported():
+					continue
+				}
+
+				name, tagged, omitted := structtag.FieldName(f, tags)
+				if omitted {
+					continue
+				}
+
+				index := append(slices.Clip(l.index), i)
+				if f.Anonymous && !tagged && embedded.Kind() == reflect.Struct {
+					next = append(next, level{typ: embedded, index: index})
+					if f.IsExported() {
+						goNames = append(goNames, structField{name: f.Name, index: index})
+					}
+					continue
+				}
+
+				candidates = append(candidates, structFieldCandidate{field: structField{name: name, index: index}, goName: f.Name, tagged: tagged})
+			}
+		}
+	}
+
+	// resolve the conflicts: the least nested field wins, or else the only tagged one among the least nested fields.
+	byName := make(map[string][]structFieldCandidate, len(candidates))
+	var names []string
+	for _, c := range candidates {
+		if _, exists := byName[c.field.name]; !exists {
+			names = append(names, c.field.name)
+		}
+		byName[c.field.name] = append(byName[c.field.name], c)
+	}
+	sf := &structFields{byName: make(map[string]structField, len(candidates))}
+	for _, name := range names {
+		c, found := dominantCandidate(byName[name])
+		if !found {
+			continue
+		}
+		sf.list = append(sf.list, c.field)
+		if c.tagged {
+			goNames = append(goNames, structField{name: c.goName, index: c.field.index})
+		}
+	}
+	slices.SortFunc(sf.list, func(a, b structField) int { return slices.Compare(a.index, b.index) })
+
+	for _, f := range sf.list {
+		sf.byName[f.name] = f
+	}
+
+	// Go names are added last, so that they never shadow a tag name.
+	sf.names = append(sf.names, sf.list...)
+	for _, f := range goNames {
+		if _, exists := sf.byName[f.name]; !exists {
+			sf.byName[f.name] = f
+			sf.names = append(sf.names, f)
+		}
+	}
+
+	return sf
+}
+
+// structFieldCandidate is a field that might be addressed by its name, if no other field has the same name.
+type structFieldCandidate struct {
+	goName string
+	field  structField
+	tagged bool
+}
+
+// dominantCandidate returns the least nested candidate, or the only tagged one among the least nested candidates.
+// The candidates have the same name and they are ordered by depth.
+func dominantCandidate(candidates []structFieldCandidate) (structFieldCandidate, bool) {
+	depth := len(candidates[0].field.index)
+	var shallowest, tagged []structFieldCandidate
+	for _, c := range candidates {
+		if len(c.field.index) != depth {
+			break
+		}
+		shallowest = append(shallowest, c)
+		if c.tagged {
+			tagged = append(tagged, c)
+		}
+	}
+
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	default:
+		return structFieldCandidate{}, false
+	}
+}
+
+// structFieldsOf returns the resolved fields of the struct type, using the cache of the traverser, or the cache of the default
+// struct tags if the traverser has none (e.g. a DefaultTraverser literal).
+func (d DefaultTraverser) structFieldsOf(t reflect.Type) *structFields {
+	if d.structFields == nil {
+		return defaultStructFieldCache.fields(t)
+	}
+
+	return d.structFields.fields(t)
+}
+
+// structFieldsOf returns the resolved fields of the struct type the way the picker's traverser resolves them, or using the default
+// struct tags if the traverser is not a DefaultTraverser.
+func (p Picker) structFieldsOf(t reflect.Type) *structFields {
+	if d, is := p.traverser.(DefaultTraverser); is {
+		return d.structFieldsOf(t)
+	}
+
+	return defaultStructFieldCache.fields(t)
+}
+
+// eachField is like `iter.ForEachField`, but it visits the fields of structs (and pointers to structs) by their resolved names
+// (see structFieldsOf), so that the names it yields (including the promoted fields of embedded structs) can be picked back.
+func (p Picker) eachField(object any, operation func(item any, meta iter.FieldOpMeta) error) error {
+	v := reflect.ValueOf(object)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return iter.ForEachField(object, operation)
+	}
+	if _, is := v.Interface().(iter.FieldIterable); is {
+		return iter.ForEachField(object, operation)
+	}
+
+	list := p.structFieldsOf(v.Type()).list
+	names := make([]string, 0, len(list))
+	values := make([]any, 0, len(list))
+	for _, f := range list {
+		if field := structFieldByIndex(v, f.index, false); field.IsValid() {
+			names = append(names, f.name)
+			values = append(values, field.Interface())
+		}
+	}
+
+	for i, value := range values {
+		if err := operation(value, iter.FieldOpMeta{Name: names[i], Length: len(values)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// structFieldByKey returns the field of the struct value that the key addresses: an index key addresses the field by its position
+// and a field key by its resolved name (see resolveStructFields). If there is no exact match, the traverser's field matching is used.
+// If alloc is true, nil embedded pointers in the way are allocated (the struct value has to be addressable), otherwise the field is not found.
+func (d DefaultTraverser) structFieldByKey(v reflect.Value, key Key, alloc bool) reflect.Value {
+	switch {
+	case key.IsIndex():
+		return v.Field(key.Index)
+
+	case key.IsField():
+		fields := d.structFieldsOf(v.Type())
+		if f, found := fields.byName[key.Name]; found {
+			return structFieldByIndex(v, f.index, alloc)
+		}
+		if f, found := d.fuzzyStructField(fields, key.Name); found {
+			return structFieldByIndex(v, f.index, alloc)
+		}
+	}
+
+	return d.nilVal
+}
+
+// structFieldByIndex is like reflect.Value.FieldByIndex, but instead of panicking on a nil embedded pointer, it returns an invalid value
+// or allocates the pointer if alloc is true and the pointer is settable.
+func structFieldByIndex(v reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// WithMethodGetters enables resolving a field key to the value returned by a zero-arg exported method, when no field matches the key.
+// For a key `name`, only the methods `GetName()` and `Name()` are tried (in this order), and the method has to return a single value or
+// a value and an error (so e.g. `Close() error` is never called). Methods with pointer receivers are resolved for struct values too
+// (using a copy of the value). The methods of the excluded types (and of pointers to them) are never called, which is the way to opt out
+// types whose zero-arg methods have side effects (e.g. `Stop()` or `Next()`).
+func WithMethodGetters(exclude ...reflect.Type) DefaultTraverserOption {
+	return func(d *DefaultTraverser) {
+		d.methodGetters = true
+		d.getterExclusions = exclude
+	}
+}
+
+// getterExcluded returns true if the type (or the type it points to) is excluded from the method getters.
+func (d DefaultTraverser) getterExcluded(t reflect.Type) bool {
+	for _, excluded := range d.getterExclusions {
+		if t == excluded || (t.Kind() == reflect.Pointer && t.Elem() == excluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// callGetter calls the getter method of the item that corresponds to the name. It returns false if there is no such method.
+func (d DefaultTraverser) callGetter(item any, name string) (result any, found bool, err error) {
+	v := reflect.ValueOf(item)
+	if !v.IsValid() || name == "" || d.getterExcluded(v.Type()) {
+		return nil, false, nil
+	}
+
+	first, size := utf8.DecodeRuneInString(name)
+	exported := string(unicode.ToUpper(first)) + name[size:]
+	for _, methodName := range [...]string{"Get" + exported, exported} {
+		m := v.MethodByName(methodName)
+		if !m.IsValid() && v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			m = addressableCopy(v).Addr().MethodByName(methodName)
+		}
+		if !m.IsValid() || !isGetter(m.Type()) {
+			continue
+		}
+
+		found = true
+		defer errorsx.RecoverPanicToError(&err)
+		out := m.Call(nil)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, true, out[1].Interface().(error) //nolint:forcetypeassert // checked by isGetter.
+		}
+		return out[0].Interface(), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// isGetter returns true if the method has no arguments and returns a value, or a value and an error.
+func isGetter(m reflect.Type) bool {
+	if m.NumIn() != 0 || (m.NumOut() > 0 && m.Out(0) == errorType) {
+		return false
+	}
+
+	switch m.NumOut() {
+	case 1:
+		return true
+	case 2:
+		return m.Out(1) == errorType
+	default:
+		return false
+	}
+}
+
+var errorType = reflect.TypeFor[error]() //nolint:gochecknoglobals
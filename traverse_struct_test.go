@@ -0,0 +1,383 @@
+package pick
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ifnotnil/x/tst"
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTraverserStructTags(t *testing.T) {
+	type base struct {
+		BaseID int `json:"base_id"`
+	}
+	type user struct {
+		base
+		Secret   string `json:"-"`
+		Dash     string `json:"-,"`
+		Name     string `json:"name,omitempty" yaml:"user_name"`
+		Alias    string `json:"Name"`
+		Config   string `config:"cfg"`
+		Untagged string
+		UserID   int `json:"user_id"`
+	}
+
+	u := user{base: base{BaseID: 7}, Secret: "s", Dash: "d", Name: "alice", Alias: "al", Config: "c", Untagged: "u", UserID: 1}
+
+	tests := map[string]struct {
+		traverser     DefaultTraverser
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+	}{
+		"json tag": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("user_id")},
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"go name fallback": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("UserID")},
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"tag with options": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("name")},
+			expected:      "alice",
+			errorAsserter: tst.NoError(),
+		},
+		"tag name shadows go name": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("Name")},
+			expected:      "al",
+			errorAsserter: tst.NoError(),
+		},
+		"second default tag": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("cfg")},
+			expected:      "c",
+			errorAsserter: tst.NoError(),
+		},
+		"untagged": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("Untagged")},
+			expected:      "u",
+			errorAsserter: tst.NoError(),
+		},
+		"omitted by tag": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("Secret")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"dash name": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("-")},
+			expected:      "d",
+			errorAsserter: tst.NoError(),
+		},
+		"promoted field by go name": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("BaseID")},
+			expected:      7,
+			errorAsserter: tst.NoError(),
+		},
+		"unexported": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter()),
+			keys:          []Key{Field("base")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"custom tags": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter(), WithStructTags("yaml")),
+			keys:          []Key{Field("user_name")},
+			expected:      "alice",
+			errorAsserter: tst.NoError(),
+		},
+		"custom tags ignore the default ones": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter(), WithStructTags("yaml")),
+			keys:          []Key{Field("user_id")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"custom tags omitted field is addressable": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter(), WithStructTags("yaml")),
+			keys:          []Key{Field("Secret")},
+			expected:      "s",
+			errorAsserter: tst.NoError(),
+		},
+		"no tags": {
+			traverser:     NewDefaultTraverser(NewDefaultConverter(), WithStructTags()),
+			keys:          []Key{Field("Name")},
+			expected:      "alice",
+			errorAsserter: tst.NoError(),
+		},
+		"zero value traverser uses the default tags": {
+			traverser:     DefaultTraverser{keyConverter: NewDefaultConverter()},
+			keys:          []Key{Field("user_id")},
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.traverser.Retrieve(u, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+
+			// the same field through a pointer.
+			got, err = tc.traverser.Retrieve(&u, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+}
+
+func TestPickerStructTags(t *testing.T) {
+	type item struct {
+		ItemID int    `json:"item_id"`
+		Hidden string `json:"-"`
+		Label  string `json:"label"`
+	}
+	type order struct {
+		Items []item `json:"items"`
+	}
+
+	p := Wrap(&order{Items: []item{{ItemID: 1, Label: "a", Hidden: "h"}, {ItemID: 2, Label: "b"}}})
+
+	id, err := p.Int("items[1].item_id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, id, 2)
+
+	ids, err := Get[[]int](p, "items[*].item_id")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, ids, []int{1, 2})
+
+	// wildcard and recursive descent report the tag names and skip the omitted fields.
+	matches, err := p.Matches("items[0].*")
+	require.NoError(t, err)
+	paths := make([]string, 0, len(matches))
+	for _, m := range matches {
+		paths = append(paths, DotNotation{}.Format(m.Path...))
+	}
+	testingx.AssertEqual(t, paths, []string{"items[0].item_id", "items[0].label"})
+
+	require.NoError(t, p.Set("items[0].label", "x"))
+	label, err := p.String("items[0].label")
+	require.NoError(t, err)
+	testingx.AssertEqual(t, label, "x")
+
+	tst.ErrorIs(ErrFieldNotFound)(t, p.Set("items[0].Hidden", "x"))
+
+	// the struct and its JSON form are addressed by the same selectors.
+	flat, err := p.Flatten()
+	require.NoError(t, err)
+	for selector, value := range flat {
+		got, err := p.Any(selector)
+		require.NoError(t, err)
+		testingx.AssertEqual(t, got, value)
+	}
+}
+
+func TestDefaultTraverserEmbeddedStructs(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `json:"created_by"`
+		Version   int    `json:"version"`
+	}
+	type Meta struct {
+		Version int
+		Name    string
+	}
+	type Other struct {
+		Name string
+	}
+	type Owner struct {
+		Email string `json:"email"`
+	}
+	type doc struct {
+		*Owner
+		Audit
+		Meta
+		Other
+		Location Other `json:"location"`
+		ID       int   `json:"id"`
+	}
+
+	d := doc{
+		Audit:    Audit{CreatedBy: "alice", Version: 2},
+		Meta:     Meta{Version: 1, Name: "meta"},
+		Other:    Other{Name: "other"},
+		Location: Other{Name: "here"},
+		ID:       3,
+	}
+
+	tests := map[string]struct {
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+	}{
+		"promoted tagged field": {
+			keys:          []Key{Field("created_by")},
+			expected:      "alice",
+			errorAsserter: tst.NoError(),
+		},
+		"tagged field wins equally nested conflict": {
+			keys:          []Key{Field("version")},
+			expected:      2,
+			errorAsserter: tst.NoError(),
+		},
+		"untagged field of a different name": {
+			keys:          []Key{Field("Version")},
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"ambiguous field": {
+			keys:          []Key{Field("Name")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"embedded struct by go name": {
+			keys:          []Key{Field("Other"), Field("Name")},
+			expected:      "other",
+			errorAsserter: tst.NoError(),
+		},
+		"tagged struct is not promoted": {
+			keys:          []Key{Field("location"), Field("Name")},
+			expected:      "here",
+			errorAsserter: tst.NoError(),
+		},
+		"field of nil embedded pointer": {
+			keys:          []Key{Field("email")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+	}
+
+	traverser := NewDefaultTraverser(NewDefaultConverter())
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := traverser.Retrieve(d, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	t.Run("set allocates nil embedded pointer", func(t *testing.T) {
+		target := d
+		_, err := traverser.Set(&target, []Key{Field("email")}, "a@b.c")
+		require.NoError(t, err)
+		require.NotNil(t, target.Owner)
+		testingx.AssertEqual(t, target.Email, "a@b.c")
+
+		got, err := traverser.Retrieve(target, []Key{Field("email")})
+		require.NoError(t, err)
+		testingx.AssertEqual(t, got, "a@b.c")
+	})
+}
+
+var errGetterTest = errors.New("getter error")
+
+type getterTestItem struct {
+	FirstName string
+	LastName  string
+	Price     float64
+}
+
+func (g getterTestItem) FullName() string { return g.FirstName + " " + g.LastName }
+
+func (g *getterTestItem) GetTotal() float64 { return g.Price * 2 }
+
+func (g getterTestItem) Label() (string, error) { return "", errGetterTest }
+
+func (g getterTestItem) Discount(float64) float64 { return 0 }
+
+func (g getterTestItem) Broken() int { panic("broken") }
+
+func (g getterTestItem) Close() error { panic("close is not a getter") }
+
+func TestDefaultTraverserMethodGetters(t *testing.T) {
+	item := getterTestItem{FirstName: "John", LastName: "Doe", Price: 2.5}
+
+	tests := map[string]struct {
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+	}{
+		"value receiver": {
+			keys:          []Key{Field("fullName")},
+			expected:      "John Doe",
+			errorAsserter: tst.NoError(),
+		},
+		"pointer receiver with get prefix": {
+			keys:          []Key{Field("total")},
+			expected:      5.0,
+			errorAsserter: tst.NoError(),
+		},
+		"field wins over method": {
+			keys:          []Key{Field("FirstName")},
+			expected:      "John",
+			errorAsserter: tst.NoError(),
+		},
+		"getter error": {
+			keys:          []Key{Field("label")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(errGetterTest),
+		},
+		"method with arguments": {
+			keys:          []Key{Field("discount")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"error only result": {
+			keys:          []Key{Field("close")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"panic": {
+			keys:          []Key{Field("broken")},
+			expected:      nil,
+			errorAsserter: tst.Error(),
+		},
+		"missing": {
+			keys:          []Key{Field("missing")},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+	}
+
+	traverser := NewDefaultTraverser(NewDefaultConverter(), WithMethodGetters())
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := traverser.Retrieve(item, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+
+			got, err = traverser.Retrieve(&item, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	t.Run("excluded type", func(t *testing.T) {
+		excluding := NewDefaultTraverser(NewDefaultConverter(), WithMethodGetters(reflect.TypeFor[getterTestItem]()))
+		for _, data := range []any{item, &item} {
+			_, err := excluding.Retrieve(data, []Key{Field("fullName")})
+			tst.ErrorIs(ErrFieldNotFound)(t, err)
+		}
+
+		got, err := excluding.Retrieve(map[string]any{"item": item}, []Key{Field("item"), Field("FirstName")})
+		require.NoError(t, err)
+		testingx.AssertEqual(t, got, any("John"))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		_, err := NewDefaultTraverser(NewDefaultConverter()).Retrieve(item, []Key{Field("fullName")})
+		tst.ErrorIs(ErrFieldNotFound)(t, err)
+	})
+}
@@ -1,11 +1,15 @@
 package pick
 
 import (
+	"iter"
+	"maps"
+	"sync"
 	"testing"
 
 	"github.com/ifnotnil/x/tst"
 	"github.com/moukoublen/pick/internal/testingx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultTraverser(t *testing.T) {
@@ -299,6 +303,83 @@ func TestDefaultTraverser(t *testing.T) {
 			expected:      true,
 			errorAsserter: tst.NoError(),
 		},
+		"wildcard on slice": {
+			input:         []any{map[string]any{"id": 1}, map[string]any{"id": 2}, map[string]any{"other": 3}},
+			keys:          []Key{Wildcard(), Field("id")},
+			expected:      []any{1, 2},
+			errorAsserter: tst.NoError(),
+		},
+
+		"wildcard on map is ordered by key": {
+			input:         map[string]any{"b": map[string]any{"e": "b@"}, "a": map[string]any{"e": "a@"}, "c": map[string]any{"e": "c@"}},
+			keys:          []Key{Wildcard(), Field("e")},
+			expected:      []any{"a@", "b@", "c@"},
+			errorAsserter: tst.NoError(),
+		},
+
+		"wildcard on map with int keys": {
+			input:         map[int]string{10: "ten", 2: "two", 1: "one"},
+			keys:          []Key{Wildcard()},
+			expected:      []any{"one", "two", "ten"},
+			errorAsserter: tst.NoError(),
+		},
+
+		"wildcard on struct": {
+			input:         &itemOne{FieldOne: "test", FieldTwo: 123},
+			keys:          []Key{Wildcard()},
+			expected:      []any{"test", 123},
+			errorAsserter: tst.NoError(),
+		},
+
+		"wildcard on typed slice and pointers": {
+			input:         []*itemOne{{FieldOne: "a"}, nil, {FieldOne: "b"}},
+			keys:          []Key{Wildcard(), Field("FieldOne")},
+			expected:      []any{"a", "b"},
+			errorAsserter: tst.NoError(),
+		},
+
+		"nested wildcards flatten": {
+			input:         map[string]any{"items": []any{[]any{1, 2}, []int{3}, "scalar"}},
+			keys:          []Key{Field("items"), Wildcard(), Wildcard()},
+			expected:      []any{1, 2, 3},
+			errorAsserter: tst.NoError(),
+		},
+
+		"wildcard no matches": {
+			input:         map[string]any{"items": []any{}},
+			keys:          []Key{Field("items"), Wildcard(), Field("id")},
+			expected:      []any{},
+			errorAsserter: tst.NoError(),
+		},
+
+		"wildcard with error after fan out": {
+			input:    map[string]any{"items": []any{map[int]string{1: "one"}}},
+			keys:     []Key{Field("items"), Wildcard(), Field("one")},
+			expected: nil,
+			errorAsserter: tst.All(
+				tst.ErrorIs(ErrKeyConvert),
+				tst.ErrorOfType[*TraverseError](
+					func(t tst.TestingT, te *TraverseError) { //nolint:thelper
+						assert.Equal(t, []Key{Field("items"), Wildcard(), Field("one")}, te.Path())
+					},
+				),
+			),
+		},
+
+		"wildcard skips elements that do not match": {
+			input:         map[string]any{"items": []any{map[string]any{"two": 2}, map[int]string{1: "one"}, map[string]any{"one": 1}}},
+			keys:          []Key{Field("items"), Wildcard(), Field("1")},
+			expected:      []any{"one"},
+			errorAsserter: tst.NoError(),
+		},
+
+		"recursive descent skips elements whose keys cannot be converted": {
+			input:         map[string]any{"items": []any{map[int]string{1: "one"}, []any{"a"}, map[string]any{"one": 1}}},
+			keys:          []Key{Field("items"), RecursiveDescent(), Field("one")},
+			expected:      []any{1},
+			errorAsserter: tst.NoError(),
+		},
+
 		"index access slice of foo level 1": {
 			input:         []foo{{A: 1}, {A: 2}, {A: 3}},
 			keys:          []Key{Index(1)},
@@ -533,6 +614,91 @@ func BenchmarkDefaultTraverser(b *testing.B) {
 	}
 }
 
+func TestDefaultTraverserRetrieveMatches(t *testing.T) {
+	type inner struct {
+		ID   int
+		Next *inner
+	}
+
+	cyclic := map[string]any{"id": 9}
+	cyclic["self"] = cyclic
+
+	tests := map[string]struct {
+		input         any
+		expected      []Match
+		errorAsserter tst.ErrorAssertionFunc
+		keys          []Key
+	}{
+		"no multiple keys": {
+			input:         map[string]any{"a": []any{1, 2, 3}},
+			keys:          []Key{Field("a"), Index(-1)},
+			expected:      []Match{{Value: 3, Path: []Key{Field("a"), Index(2)}}},
+			errorAsserter: tst.NoError(),
+		},
+		"recursive descent": {
+			input: map[string]any{
+				"id": 1,
+				"items": []any{
+					map[string]any{"id": 2, "children": []any{map[string]any{"id": 3}}},
+					map[string]any{"name": "no id"},
+				},
+				"owner": map[string]any{"id": 4},
+			},
+			keys: []Key{RecursiveDescent(), Field("id")},
+			expected: []Match{
+				{Value: 1, Path: []Key{Field("id")}},
+				{Value: 2, Path: []Key{Field("items"), Index(0), Field("id")}},
+				{Value: 3, Path: []Key{Field("items"), Index(0), Field("children"), Index(0), Field("id")}},
+				{Value: 4, Path: []Key{Field("owner"), Field("id")}},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"recursive descent in structs": {
+			input: map[string]any{"root": &inner{ID: 1, Next: &inner{ID: 2}}},
+			keys:  []Key{Field("root"), RecursiveDescent(), Field("ID")},
+			expected: []Match{
+				{Value: 1, Path: []Key{Field("root"), Field("ID")}},
+				{Value: 2, Path: []Key{Field("root"), Field("Next"), Field("ID")}},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"recursive descent with cycle": {
+			input: cyclic,
+			keys:  []Key{RecursiveDescent(), Field("id")},
+			expected: []Match{
+				{Value: 9, Path: []Key{Field("id")}},
+				{Value: 9, Path: []Key{Field("self"), Field("id")}},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"wildcard": {
+			input: map[string]any{"users": map[string]any{"b": map[string]any{"e": "b@"}, "a": map[string]any{"e": "a@"}}},
+			keys:  []Key{Field("users"), Wildcard(), Field("e")},
+			expected: []Match{
+				{Value: "a@", Path: []Key{Field("users"), Field("a"), Field("e")}},
+				{Value: "b@", Path: []Key{Field("users"), Field("b"), Field("e")}},
+			},
+			errorAsserter: tst.NoError(),
+		},
+		"not found before fan out": {
+			input:         map[string]any{"users": map[string]any{}},
+			keys:          []Key{Field("missing"), Wildcard()},
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+	}
+
+	dt := NewDefaultTraverser(NewDefaultConverter())
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := dt.RetrieveMatches(tc.input, tc.keys)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+}
+
 func TestTraverseError(t *testing.T) { //nolint:thelper
 	err1 := NewTraverseError("not good", []Key{Field("one")}, 0, nil)
 	testingx.AssertEqual(t, err1.Error(), "selector: one : not good")
@@ -548,4 +714,286 @@ func TestTraverseError(t *testing.T) { //nolint:thelper
 	testingx.AssertEqual(t, err3.Error(), "selector: one.two : not good: field not found")
 	tst.ErrorIs(ErrFieldNotFound)(t, err3)
 	testingx.AssertEqual(t, err3.Path(), []Key{Field("one"), Field("two")})
+
+	err4 := NewTraverseError("not good", []Key{Field("one"), Index(2), Field("a/b")}, 2, nil).WithNotation(JSONPointerNotation{})
+	testingx.AssertEqual(t, err4.Error(), "selector: /one/2/a~1b : not good")
+}
+
+// testOrderedRecord is a custom container with fields that implements Traversable, iter.FieldIterable and iter.Lengther.
+type testOrderedRecord struct {
+	values map[string]any
+	keys   []string
+}
+
+func (r testOrderedRecord) PickKey(key Key) (any, error) {
+	if v, found := r.values[key.Name]; key.IsField() && found {
+		return v, nil
+	}
+	return nil, ErrFieldNotFound
+}
+
+func (r testOrderedRecord) PickEachField(fn func(name string, item any) error) error {
+	for _, k := range r.keys {
+		if err := fn(k, r.values[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r testOrderedRecord) PickLen() int { return len(r.keys) }
+
+// testSparseList is a custom collection that implements Traversable, iter.Iterable and iter.Lengther.
+type testSparseList struct {
+	values map[int]any
+	length int
+}
+
+func (s testSparseList) PickKey(key Key) (any, error) {
+	if !key.IsIndex() {
+		return nil, ErrFieldNotFound
+	}
+	i := key.Index
+	if i < 0 {
+		i += s.length
+	}
+	if i < 0 || i >= s.length {
+		return nil, ErrIndexOutOfRange
+	}
+	return s.values[i], nil
+}
+
+func (s testSparseList) PickEach(fn func(index int, item any) error) error {
+	for i := range s.length {
+		if err := fn(i, s.values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s testSparseList) PickLen() int { return s.length }
+
+func TestDefaultTraverserTraversable(t *testing.T) {
+	users := testSparseList{
+		length: 3,
+		values: map[int]any{
+			0: testOrderedRecord{keys: []string{"name", "age"}, values: map[string]any{"name": "alice", "age": 30}},
+			2: &testOrderedRecord{keys: []string{"name"}, values: map[string]any{"name": "bob"}},
+		},
+	}
+	p := Wrap(map[string]any{"users": users})
+
+	tests := map[string]struct {
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		selector      string
+	}{
+		"index and field": {
+			selector:      "users[0].name",
+			expected:      "alice",
+			errorAsserter: tst.NoError(),
+		},
+		"pointer to custom container": {
+			selector:      "users[2].name",
+			expected:      "bob",
+			errorAsserter: tst.NoError(),
+		},
+		"negative index": {
+			selector:      "users[-1].name",
+			expected:      "bob",
+			errorAsserter: tst.NoError(),
+		},
+		"missing field": {
+			selector:      "users[0].email",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"out of range": {
+			selector:      "users[5]",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrIndexOutOfRange),
+		},
+		"wildcard": {
+			selector:      "users[*].name",
+			expected:      []any{"alice", "bob"},
+			errorAsserter: tst.NoError(),
+		},
+		"slice": {
+			selector:      "users[1:].name",
+			expected:      []any{"bob"},
+			errorAsserter: tst.NoError(),
+		},
+		"fields wildcard in custom order": {
+			selector:      "users[0].*",
+			expected:      []any{"alice", 30},
+			errorAsserter: tst.NoError(),
+		},
+		"recursive descent": {
+			selector:      "..name",
+			expected:      []any{"alice", "bob"},
+			errorAsserter: tst.NoError(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := p.Any(tc.selector)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	t.Run("Len", func(t *testing.T) {
+		l, err := p.Len("users")
+		require.NoError(t, err)
+		testingx.AssertEqual(t, l, 3)
+	})
+
+	t.Run("Each", func(t *testing.T) {
+		var indices []int
+		err := Each(p, "users", func(index int, _ Picker, totalLength int) error {
+			testingx.AssertEqual(t, totalLength, 3)
+			indices = append(indices, index)
+			return nil
+		})
+		require.NoError(t, err)
+		testingx.AssertEqual(t, indices, []int{0, 1, 2})
+	})
+
+	t.Run("EachField", func(t *testing.T) {
+		var fields []string
+		err := EachField(p, "users[0]", func(field string, _ Picker, _ int) error {
+			fields = append(fields, field)
+			return nil
+		})
+		require.NoError(t, err)
+		testingx.AssertEqual(t, fields, []string{"name", "age"})
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		names, err := Map(p, "users", func(p Picker) (string, error) { return p.String("name") })
+		require.ErrorIs(t, err, ErrFieldNotFound) // the nil element has no name.
+		testingx.AssertEqual(t, names, []string(nil))
+
+		ids, err := Map(p, "users[*].name", func(p Picker) (string, error) { return p.String("") })
+		require.NoError(t, err)
+		testingx.AssertEqual(t, ids, []string{"alice", "bob"})
+	})
+}
+
+func TestDefaultTraverserSyncMapAndSequences(t *testing.T) {
+	state := &sync.Map{}
+	state.Store("b", map[string]any{"id": 2})
+	state.Store("a", map[string]any{"id": 1})
+	state.Store(3, "three")
+
+	evaluated := 0
+	numbers := func(yield func(int) bool) {
+		for i := range 100 {
+			evaluated++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	names := maps.All(map[string]string{"x": "alice"})
+	queue := make(chan map[string]any, 2)
+	queue <- map[string]any{"id": 10}
+	queue <- map[string]any{"id": 20}
+	close(queue)
+
+	p := Wrap(map[string]any{
+		"state":   state,
+		"numbers": iter.Seq[int](numbers),
+		"names":   names,
+		"queue":   (<-chan map[string]any)(queue),
+	})
+
+	tests := map[string]struct {
+		expected      any
+		errorAsserter tst.ErrorAssertionFunc
+		selector      string
+	}{
+		"sync.Map by field": {
+			selector:      "state.a.id",
+			expected:      1,
+			errorAsserter: tst.NoError(),
+		},
+		"sync.Map by index": {
+			selector:      "state[3]",
+			expected:      "three",
+			errorAsserter: tst.NoError(),
+		},
+		"sync.Map missing": {
+			selector:      "state.c",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+		"sync.Map wildcard": {
+			selector:      "state.*.id",
+			expected:      []any{1, 2},
+			errorAsserter: tst.NoError(),
+		},
+		"seq index": {
+			selector:      "numbers[5]",
+			expected:      5,
+			errorAsserter: tst.NoError(),
+		},
+		"seq negative index": {
+			selector:      "numbers[-1]",
+			expected:      99,
+			errorAsserter: tst.NoError(),
+		},
+		"seq out of range": {
+			selector:      "numbers[100]",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrIndexOutOfRange),
+		},
+		"seq slice": {
+			selector:      "numbers[1:3]",
+			expected:      []any{1, 2},
+			errorAsserter: tst.NoError(),
+		},
+		"seq2 values": {
+			selector:      "names[0]",
+			expected:      "alice",
+			errorAsserter: tst.NoError(),
+		},
+		"seq field": {
+			selector:      "numbers.a",
+			expected:      nil,
+			errorAsserter: tst.ErrorIs(ErrFieldNotFound),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := p.Any(tc.selector)
+			tc.errorAsserter(t, err)
+			testingx.AssertEqual(t, got, tc.expected)
+		})
+	}
+
+	t.Run("seq evaluated up to the index", func(t *testing.T) {
+		evaluated = 0
+		got, err := p.Int("numbers[2]")
+		require.NoError(t, err)
+		testingx.AssertEqual(t, got, 2)
+		testingx.AssertEqual(t, evaluated, 3)
+	})
+
+	t.Run("Len and Map", func(t *testing.T) {
+		l, err := p.Len("numbers")
+		require.NoError(t, err)
+		testingx.AssertEqual(t, l, 100)
+
+		l, err = p.Len("state")
+		require.NoError(t, err)
+		testingx.AssertEqual(t, l, 3)
+
+		ids, err := Map(p, "queue", func(p Picker) (int, error) { return p.Int("id") })
+		require.NoError(t, err)
+		testingx.AssertEqual(t, ids, []int{10, 20})
+	})
 }
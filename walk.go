@@ -0,0 +1,148 @@
+package pick
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+
+	"github.com/moukoublen/pick/iter"
+)
+
+// WalkAction is returned by the function of Walk in order to control the walk.
+type WalkAction int
+
+const (
+	// WalkContinue continues the walk with the children of the current node (if any).
+	WalkContinue WalkAction = iota
+	// WalkSkip skips the children of the current node.
+	WalkSkip
+	// WalkStop stops the walk.
+	WalkStop
+)
+
+// Walk visits every node of the data depth-first (pre-order), starting from the root (which has an empty path), and calls fn with
+// the path of the node and the node wrapped in a Picker. Maps are visited in sorted key order, slices/arrays in index order and
+// structs in field order, using the field names that the picker's traverser resolves (see WithStructTags), including the promoted
+// fields of embedded structs.
+// Pointers are dereferenced. Pointers, maps and slices that are already being walked (cycles) are visited but their children are not
+// walked again. The path passed to fn is not reused, so it can be retained.
+func Walk(p Picker, fn func(path []Key, value Picker) WalkAction) error {
+	_, err := walk(p, nil, p.data, visiting{}, fn)
+	return err
+}
+
+type walkField struct {
+	value any
+	name  string
+}
+
+// walk visits the node and its children and returns false if the walk has to stop.
+func walk(p Picker, path []Key, node any, walking visiting, fn func(path []Key, value Picker) WalkAction) (bool, error) {
+	switch fn(path, p.Wrap(node)) {
+	case WalkStop:
+		return false, nil
+	case WalkSkip:
+		return true, nil
+	case WalkContinue:
+	}
+
+	ref, entered := walking.enter(node)
+	if !entered {
+		return true, nil
+	}
+	defer walking.leave(ref)
+
+	node = indirect(node)
+	switch reflect.ValueOf(node).Kind() {
+	case reflect.Map, reflect.Struct:
+		var fields []walkField
+		err := p.eachField(node, func(item any, meta iter.FieldOpMeta) error {
+			fields = append(fields, walkField{name: meta.Name, value: item})
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
+		if reflect.ValueOf(node).Kind() == reflect.Map {
+			slices.SortFunc(fields, func(a, b walkField) int { return cmp.Compare(a.name, b.name) })
+		}
+		for _, f := range fields {
+			if cont, err := walk(p, appendKey(path, Field(f.name)), f.value, walking, fn); !cont || err != nil {
+				return cont, err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		v := reflect.ValueOf(node)
+		for i := range v.Len() {
+			if cont, err := walk(p, appendKey(path, Index(i)), v.Index(i).Interface(), walking, fn); !cont || err != nil {
+				return cont, err
+			}
+		}
+
+	default:
+	}
+
+	return true, nil
+}
+
+// indirect dereferences (non nil) pointers.
+func indirect(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return v
+	}
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	return rv.Interface()
+}
+
+// reference identifies a (non nil) pointer or map, or a non empty slice, by its type and address (and length for slices),
+// in order to detect cycles.
+type reference struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// referenceOf returns the reference of pointers, maps and slices. It returns false for any other value.
+func referenceOf(v reflect.Value) (reference, bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if v.IsNil() {
+			return reference{}, false
+		}
+		return reference{typ: v.Type(), ptr: v.Pointer()}, true
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return reference{}, false
+		}
+		return reference{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}, true
+	default:
+		return reference{}, false
+	}
+}
+
+// visiting holds the references that are being visited by a recursive walk, in order to detect cycles.
+type visiting map[reference]struct{}
+
+// enter marks the value as being visited and returns false if it is already being visited (a cycle).
+// Otherwise leave has to be called with the returned reference once the value is visited.
+func (w visiting) enter(v any) (reference, bool) {
+	ref, isRef := referenceOf(reflect.ValueOf(v))
+	if !isRef {
+		return reference{}, true
+	}
+	if _, found := w[ref]; found {
+		return reference{}, false
+	}
+	w[ref] = struct{}{}
+
+	return ref, true
+}
+
+func (w visiting) leave(ref reference) {
+	delete(w, ref)
+}
@@ -0,0 +1,144 @@
+package pick
+
+import (
+	"testing"
+
+	"github.com/moukoublen/pick/internal/testingx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	type contact struct {
+		Email   string `json:"email"`
+		Phone   *string
+		private string
+	}
+	type user struct {
+		Contact *contact `json:"contact"`
+		Tags    []string `json:"tags"`
+		Name    string   `json:"name"`
+	}
+
+	phone := "555"
+	data := map[string]any{
+		"users": []any{
+			user{Name: "alice", Tags: []string{"a"}, Contact: &contact{Email: "a@b.c", Phone: &phone, private: "x"}},
+		},
+		"count": 1,
+		"meta":  nil,
+	}
+
+	type visit struct {
+		value any
+		path  string
+	}
+	collect := func(action func(path []Key) WalkAction) []visit {
+		var visits []visit
+		err := Walk(Wrap(data), func(path []Key, value Picker) WalkAction {
+			visits = append(visits, visit{path: DotNotation{}.Format(path...), value: value.Data()})
+			return action(path)
+		})
+		require.NoError(t, err)
+		return visits
+	}
+
+	paths := func(visits []visit) []string {
+		p := make([]string, 0, len(visits))
+		for _, v := range visits {
+			p = append(p, v.path)
+		}
+		return p
+	}
+
+	all := collect(func([]Key) WalkAction { return WalkContinue })
+	testingx.AssertEqual(t, paths(all), []string{
+		"",
+		"count",
+		"meta",
+		"users",
+		"users[0]",
+		"users[0].contact",
+		"users[0].contact.email",
+		"users[0].contact.Phone",
+		"users[0].tags",
+		"users[0].tags[0]",
+		"users[0].name",
+	})
+	testingx.AssertEqual(t, all[6].value, any("a@b.c"))
+	testingx.AssertEqual(t, all[7].value, any(&phone))
+
+	skipped := collect(func(path []Key) WalkAction {
+		if len(path) == 2 {
+			return WalkSkip
+		}
+		return WalkContinue
+	})
+	testingx.AssertEqual(t, paths(skipped), []string{"", "count", "meta", "users", "users[0]"})
+
+	stopped := collect(func(path []Key) WalkAction {
+		if len(path) == 3 {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	testingx.AssertEqual(t, paths(stopped), []string{"", "count", "meta", "users", "users[0]", "users[0].contact"})
+}
+
+func TestWalkFindLeaves(t *testing.T) {
+	p, err := WrapJSON([]byte(`{"a": "long string", "b": ["short", null, {"c": "another long one"}], "d": null}`))
+	require.NoError(t, err)
+
+	var long, nulls []string
+	err = Walk(p, func(path []Key, value Picker) WalkAction {
+		switch v := value.Data().(type) {
+		case string:
+			if len(v) > 5 {
+				long = append(long, DotNotation{}.Format(path...))
+			}
+		case nil:
+			nulls = append(nulls, DotNotation{}.Format(path...))
+		}
+		return WalkContinue
+	})
+	require.NoError(t, err)
+	testingx.AssertEqual(t, long, []string{"a", "b[2].c"})
+	testingx.AssertEqual(t, nulls, []string{"b[1]", "d"})
+}
+
+func TestWalkCycles(t *testing.T) {
+	type node struct {
+		Parent   *node   `json:"parent"`
+		Name     string  `json:"name"`
+		Children []*node `json:"children"`
+	}
+
+	root := &node{Name: "root"}
+	root.Children = []*node{{Name: "child", Parent: root}}
+	self := map[string]any{"name": "self"}
+	self["self"] = self
+
+	walkPaths := func(data any) []string {
+		var paths []string
+		err := Walk(Wrap(data), func(path []Key, _ Picker) WalkAction {
+			paths = append(paths, DotNotation{}.Format(path...))
+			return WalkContinue
+		})
+		require.NoError(t, err)
+		return paths
+	}
+
+	// the nodes that are reached again are visited, but their children are not.
+	testingx.AssertEqual(t, walkPaths(root), []string{
+		"", "parent", "name", "children", "children[0]", "children[0].parent", "children[0].name", "children[0].children",
+	})
+	testingx.AssertEqual(t, walkPaths(self), []string{"", "name", "self"})
+
+	flat, err := Wrap(root).Flatten()
+	require.NoError(t, err)
+	testingx.AssertEqual(t, flat, map[string]any{
+		"parent":               (*node)(nil),
+		"name":                 "root",
+		"children[0].name":     "child",
+		"children[0].children": []*node(nil),
+	})
+}